@@ -234,6 +234,8 @@ func makeFullNode(ctx *cli.Context) (*node.Node, ethapi.Backend) {
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
 	}
+	// Serve the consensus liveness to orchestration probes
+	utils.RegisterHotStuffHealth(stack, backend)
 	return stack, backend
 }
 
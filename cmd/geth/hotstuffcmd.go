@@ -0,0 +1,279 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	hotstuffBackend "github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/recorder"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/replay"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	cli "gopkg.in/urfave/cli.v1"
+)
+
+var (
+	replayStepFlag = cli.BoolFlag{
+		Name:  "step",
+		Usage: "Wait for enter after every replayed event",
+	}
+
+	hotstuffCommand = cli.Command{
+		Name:        "hotstuff",
+		Usage:       "A set of commands for HotStuff chains",
+		Category:    "BLOCKCHAIN COMMANDS",
+		Description: "",
+		Subcommands: []cli.Command{
+			{
+				Name:      "audit",
+				Usage:     "Re-verify the seals, commit QCs and validator sets of a HotStuff chain",
+				ArgsUsage: "[<from> [<to>]]",
+				Action:    utils.MigrateFlags(auditHotStuff),
+				Category:  "BLOCKCHAIN COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.AncientFlag,
+				},
+				Description: `
+geth hotstuff audit [<from> [<to>]]
+walks the canonical chain stored in the data directory, without starting the
+node, and re-verifies every header from block <from> to block <to>, by default
+the whole chain:
+
+- the ECDSA seal of the proposer, which must be a validator
+- the commit QC, which must certify the header at its height, and whose BLS
+  signature is authenticated with the keys of the data directory and genesis
+- the validator set recorded in the header
+
+Chains whose validator set is managed by a contract are not supported.
+
+Missing headers, broken parent links and replayed QCs are reported as well. The
+command fails if any issue is found.
+`,
+			},
+			{
+				Name:      "replay",
+				Usage:     "Replay a consensus recording in a fresh HotStuff core",
+				ArgsUsage: "<file> [<file>...]",
+				Action:    utils.MigrateFlags(replayHotStuff),
+				Category:  "BLOCKCHAIN COMMANDS",
+				Flags: []cli.Flag{
+					utils.DataDirFlag,
+					utils.AncientFlag,
+					replayStepFlag,
+				},
+				Description: `
+geth hotstuff replay <file> [<file>...]
+feeds the events recorded by a node with HotStuff.RecordFile set into a fresh
+consensus core, in the order the node handled them, and prints every event with
+the messages the core sent in response and the view and state it reached. Rotated
+files are given oldest first, and the first one must start with the start of the
+core. The chain configuration and BLS keys are read from the data directory, as
+by the node which made the recording.
+
+The replay holds no chain state: proposals are accepted as the recording node
+accepted them, and the chain advances with the recorded heads and the blocks the
+replay commits. Messages sent by the replay are signed with an ephemeral key and
+not delivered anywhere. With --step, the replay waits for enter after every event.
+`,
+			},
+		},
+	}
+)
+
+// auditReader reads canonical headers straight from the chain database
+type auditReader struct {
+	db ethdb.Reader
+}
+
+func (r auditReader) GetHeaderByNumber(number uint64) *types.Header {
+	hash := rawdb.ReadCanonicalHash(r.db, number)
+	if hash == (common.Hash{}) {
+		return nil
+	}
+	return rawdb.ReadHeader(r.db, hash, number)
+}
+
+func auditHotStuff(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	genesis := rawdb.ReadCanonicalHash(db, 0)
+	chainConfig := rawdb.ReadChainConfig(db, genesis)
+	if chainConfig == nil || chainConfig.HotStuff == nil {
+		return errors.New("not a HotStuff chain")
+	}
+	if chainConfig.HotStuff.ValidatorContract != (common.Address{}) {
+		return errors.New("validator sets managed by a contract cannot be audited")
+	}
+	head := rawdb.ReadHeaderNumber(db, rawdb.ReadHeadHeaderHash(db))
+	if head == nil {
+		return errors.New("no head header")
+	}
+
+	if ctx.NArg() > 2 {
+		return errors.New("too many arguments")
+	}
+	from, to := uint64(0), *head
+	if ctx.NArg() > 0 {
+		n, err := strconv.ParseUint(ctx.Args()[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid start block: %v", err)
+		}
+		from = n
+	}
+	if ctx.NArg() > 1 {
+		n, err := strconv.ParseUint(ctx.Args()[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid end block: %v", err)
+		}
+		to = n
+	}
+	if from > to {
+		return fmt.Errorf("start block %d is after end block %d", from, to)
+	}
+
+	// the auditor only verifies signatures, an ephemeral key stands in for the node key
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+	config := ethconfig.Defaults.HotStuff
+	valset, signer := ethconfig.MakeHotStuffSigner(stack, chainConfig, &config, key)
+
+	start := time.Now()
+	report := hotstuffBackend.Audit(auditReader{db: db}, signer, valset, from, to)
+	for _, issue := range report.Issues {
+		fmt.Println(issue)
+	}
+	log.Info("Audited HotStuff chain", "from", report.From, "to", report.To, "headers", report.Headers, "issues", len(report.Issues), "elapsed", common.PrettyDuration(time.Since(start)))
+
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("%d integrity issues found", len(report.Issues))
+	}
+	return nil
+}
+
+func replayHotStuff(ctx *cli.Context) error {
+	if ctx.NArg() == 0 {
+		return errors.New("no recording given")
+	}
+	var records []*recorder.Record
+	for _, path := range ctx.Args() {
+		recs, err := recorder.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", path, err)
+		}
+		records = append(records, recs...)
+	}
+
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	chainConfig := rawdb.ReadChainConfig(db, rawdb.ReadCanonicalHash(db, 0))
+	if chainConfig == nil || chainConfig.HotStuff == nil {
+		return errors.New("not a HotStuff chain")
+	}
+	// the recorded core ran with the consensus settings of the chain
+	config := ethconfig.Defaults.HotStuff
+	ethconfig.ApplyHotStuffChainConfig(&config, chainConfig.HotStuff)
+
+	// the replay only authenticates recorded messages, an ephemeral key signs its own
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+	valset, signer := ethconfig.MakeHotStuffSigner(stack, chainConfig, &config, key)
+	replayer, err := replay.New(records, &config, valset, signer)
+	if err != nil {
+		return err
+	}
+
+	var (
+		step  = ctx.Bool(replayStepFlag.Name)
+		input = bufio.NewReader(os.Stdin)
+	)
+	for i, rec := range records {
+		fmt.Printf("%6d %s %-14v %s\n", i, time.Unix(0, int64(rec.Time)).Format("15:04:05.000"), rec.Kind, describeRecord(rec))
+		sent, err := replayer.Step(rec)
+		for _, msg := range sent {
+			fmt.Printf("%28s %v %v\n", "->", msg.Code, msg.View)
+		}
+		if err != nil {
+			fmt.Printf("%28s %v\n", "error:", err)
+		}
+		if view, state := replayer.State(); view != nil {
+			fmt.Printf("%28s %v %v\n", "state:", view, state)
+		}
+		if step {
+			input.ReadString('\n')
+		}
+	}
+	log.Info("Replayed consensus recording", "records", len(records), "committed", len(replayer.Committed()))
+	return nil
+}
+
+// describeRecord summarizes the content of a record
+func describeRecord(rec *recorder.Record) string {
+	switch rec.Kind {
+	case recorder.Message, recorder.Backlog, recorder.Sent:
+		msg := new(hotstuff.Message)
+		if err := rlp.DecodeBytes(rec.Data, msg); err != nil {
+			return fmt.Sprintf("undecodable message: %v", err)
+		}
+		return fmt.Sprintf("%v %v from %s", msg.Code, msg.View, rec.Src.Hex())
+	case recorder.Request:
+		var block *types.Block
+		if err := rlp.DecodeBytes(rec.Data, &block); err != nil {
+			return fmt.Sprintf("undecodable block: %v", err)
+		}
+		return fmt.Sprintf("block %d %s", block.NumberU64(), block.Hash().TerminalString())
+	case recorder.Start, recorder.FinalCommitted:
+		var header *types.Header
+		if err := rlp.DecodeBytes(rec.Data, &header); err != nil {
+			return fmt.Sprintf("undecodable header: %v", err)
+		}
+		return fmt.Sprintf("head %d %s", header.Number.Uint64(), header.Hash().TerminalString())
+	case recorder.Propose:
+		var view *hotstuff.View
+		if err := rlp.DecodeBytes(rec.Data, &view); err != nil {
+			return fmt.Sprintf("undecodable view: %v", err)
+		}
+		return view.String()
+	}
+	return ""
+}
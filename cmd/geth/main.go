@@ -298,6 +298,8 @@ func init() {
 		utils.ShowDeprecated,
 		// See snapshot.go
 		snapshotCommand,
+		// See hotstuffcmd.go
+		hotstuffCommand,
 	}
 	sort.Sort(cli.CommandsByName(app.Commands))
 
@@ -25,6 +25,7 @@ import (
 	"io/ioutil"
 	"math"
 	"math/big"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -2302,6 +2303,14 @@ func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, cfg node.C
 	}
 }
 
+// RegisterHotStuffHealth serves the consensus liveness of HotStuff nodes on the HTTP
+// server at /hotstuff/health, for orchestration probes
+func RegisterHotStuffHealth(stack *node.Node, backend ethapi.Backend) {
+	if engine, ok := backend.Engine().(interface{ HealthHandler() http.Handler }); ok {
+		stack.RegisterHandler("HotStuff health", "/hotstuff/health", engine.HealthHandler())
+	}
+}
+
 // Quorum
 //
 // Register plugin manager as a service in geth
@@ -18,8 +18,10 @@
 package utils
 
 import (
+	"encoding/json"
 	"flag"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"path"
 	"reflect"
@@ -28,7 +30,15 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
+	hotstuffBackend "github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -207,3 +217,39 @@ func TestQuorumConfigFlags(t *testing.T) {
 	assert.Equal(t, uint64(0), config.EmptyBlockPeriod, "IstanbulEmptyBlockPeriodFlag value is incorrect")
 	assert.Equal(t, true, arbitraryEthConfig.RaftMode, "RaftModeFlag value is incorrect")
 }
+
+// hotstuffHealthBackend serves the engine of an API backend
+type hotstuffHealthBackend struct {
+	ethapi.Backend
+	engine consensus.Engine
+}
+
+func (b *hotstuffHealthBackend) Engine() consensus.Engine { return b.engine }
+
+func TestRegisterHotStuffHealth(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	// total weight 6, so a quorum needs a voting power of 5
+	addrs := []common.Address{crypto.PubkeyToAddress(key.PublicKey), common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")}
+	valset := validator.NewWeightedSet(addrs, []int{3, 1, 1, 1}, hotstuff.RoundRobin)
+	config := *hotstuff.DefaultBasicConfig
+	engine := hotstuffBackend.New(&config, key, rawdb.NewMemoryDatabase(), valset, new(types.BLSInfo))
+
+	stack, err := node.New(&node.Config{HTTPHost: "127.0.0.1"})
+	require.NoError(t, err)
+	defer stack.Close()
+	RegisterHotStuffHealth(stack, &hotstuffHealthBackend{engine: engine})
+	require.NoError(t, stack.Start())
+
+	resp, err := http.Get(stack.HTTPEndpoint() + "/hotstuff/health")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var health hotstuffBackend.Health
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&health))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.False(t, health.Healthy)
+	assert.Equal(t, []string{"engine not started"}, health.Reasons)
+	assert.Equal(t, 5, health.Quorum)
+}
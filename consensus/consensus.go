@@ -61,6 +61,31 @@ type ChainReader interface {
 	ExecuteBlock(block *types.Block) (*state.StateDB, types.Receipts, []*types.Log, error)
 }
 
+// ChainWriter is implemented by chains which can write a block executed by
+// ChainReader.ExecuteBlock without running its state transition again.
+type ChainWriter interface {
+	// WriteExecutedBlock writes an agreed block with the state, receipts and logs
+	// returned by ExecuteBlock, and sets it as the head
+	WriteExecutedBlock(block *types.Block, receipts types.Receipts, logs []*types.Log, state *state.StateDB) error
+}
+
+// ChainInserter is implemented by chains which can import blocks handed over by the
+// engine, e.g. decided blocks forwarded to an observer.
+type ChainInserter interface {
+	ChainHeaderReader
+
+	// InsertChain verifies and imports a batch of blocks
+	InsertChain(chain types.Blocks) (int, error)
+}
+
+// FinalityReader is implemented by engines whose blocks are final once agreed,
+// e.g. HotStuff blocks carrying a commit QC.
+type FinalityReader interface {
+	// FinalizedHeader returns the newest finalized header of the canonical chain, or
+	// nil if none is known
+	FinalizedHeader(chain ChainHeaderReader) *types.Header
+}
+
 // Engine is an algorithm agnostic consensus engine.
 type Engine interface {
 	// Author retrieves the Ethereum address of the account that minted the given
@@ -188,3 +213,10 @@ type ExecutedBlock struct {
 	Receipts types.Receipts
 	Logs     []*types.Log
 }
+
+// SpeculativeParent is a block agreed on by the validators whose commit is still
+// running, along with a copy of the state it was executed to
+type SpeculativeParent struct {
+	Block *types.Block
+	State *state.StateDB
+}
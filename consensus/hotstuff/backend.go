@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 )
@@ -47,6 +48,19 @@ type Backend interface {
 	// GetProposer returns the proposer of the given block height
 	GetProposer(number uint64) common.Address
 
+	// CommitHistory returns the commit records of at most window blocks ending
+	// with the given height, oldest first
+	CommitHistory(number uint64, window uint64) []CommitRecord
+
+	// UpdateValidators returns the validator set governing the child of the given
+	// block if it differs from the current one, or nil. Sets managed outside the
+	// genesis, e.g. by a contract, may change at every height.
+	UpdateValidators(parent *types.Header) (ValidatorSet, error)
+
+	// SuspectCensorship reports whether the proposed block leaves out a transaction
+	// of the local pool which earlier proposals with spare gas left out as well
+	SuspectCensorship(block *types.Block, proposer common.Address) bool
+
 	// HasBadBlock returns whether the block with the hash is a bad block
 	HasBadProposal(hash common.Hash) bool
 
@@ -55,6 +69,10 @@ type Backend interface {
 
 	SealBlock(block *types.Block, prepareQC *QuorumCert) (*types.Block, error)
 
+	// Speculate hands a locked block to the miner, which builds the next block on it
+	// while the locked block is decided
+	Speculate(executed *consensus.ExecutedBlock)
+
 	Close() error
 }
 
@@ -69,6 +87,13 @@ type CoreEngine interface {
 	// CurrentSequence return current proposal height and consensus round
 	CurrentSequence() (uint64, uint64)
 
+	// RoundChanges returns the number of rounds which timed out since the engine started
+	RoundChanges() uint64
+
+	// Timeline returns the timelines of the recent views of the given height, one
+	// per round
+	Timeline(height uint64) []*timeline.View
+
 	// verify if a hash is the same as the proposed block in the current pending request
 	//
 	// this is useful when the engine is currently the speaker
@@ -83,6 +108,18 @@ type CoreEngine interface {
 	GetMessageVotes(msgs []*Message) []*Vote
 }
 
+// Status is a snapshot of the consensus state of a node, reported to monitoring
+// services such as ethstats
+type Status struct {
+	Height         uint64         // Height of the current view
+	Round          uint64         // Round of the current view
+	Leader         common.Address // Proposer of the current view
+	IsValidator    bool           // Whether the node is in the validator set
+	RoundChanges   uint64         // Rounds which timed out since the engine started
+	QCParticipants int            // Voting power of the signers of the last commit QC
+	LastCommit     time.Time      // Time of the last commit, zero if none yet
+}
+
 type HotstuffProtocol string
 
 const (
@@ -1,8 +1,13 @@
 package backend
 
 import (
+	"errors"
+
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 )
 
 // API is a user facing RPC API to allow controlling the address and voting
@@ -43,6 +48,47 @@ func (api *API) Discard(address common.Address) {
 	delete(api.hotstuff.proposals, address)
 }
 
+// ProposeBLSKey injects a BLS public key with its proof of possession, which the
+// validator votes to register in the blocks it proposes. The key is registered once
+// validators holding a quorum of the voting power voted for it.
+func (api *API) ProposeBLSKey(address common.Address, publicKey hexutil.Bytes, proof hexutil.Bytes) error {
+	multi := api.hotstuff.multiSigner()
+	if multi == nil {
+		return errors.New("BLS key votes require the MultiSig signature scheme")
+	}
+	key := &snr.BLSPublicKey{Address: address, PublicKey: publicKey, Proof: proof}
+	if err := multi.VerifyPublicKey(key); err != nil {
+		return err
+	}
+
+	api.hotstuff.sigMu.Lock()
+	defer api.hotstuff.sigMu.Unlock()
+
+	api.hotstuff.keyProposals[address] = key
+	return nil
+}
+
+// DiscardBLSKey drops a BLS public key proposal, stopping the validator from casting
+// further votes for it
+func (api *API) DiscardBLSKey(address common.Address) {
+	api.hotstuff.sigMu.Lock()
+	defer api.hotstuff.sigMu.Unlock()
+
+	delete(api.hotstuff.keyProposals, address)
+}
+
+// BLSKeyProposals returns the BLS public keys the node votes to register
+func (api *API) BLSKeyProposals() map[common.Address]hexutil.Bytes {
+	api.hotstuff.sigMu.RLock()
+	defer api.hotstuff.sigMu.RUnlock()
+
+	proposals := make(map[common.Address]hexutil.Bytes)
+	for address, key := range api.hotstuff.keyProposals {
+		proposals[address] = key.PublicKey
+	}
+	return proposals
+}
+
 // CurrentView retrieve current proposal height and round number
 func (api *API) CurrentSequence() (uint64, uint64) {
 	return api.hotstuff.core.CurrentSequence()
@@ -51,3 +97,20 @@ func (api *API) CurrentSequence() (uint64, uint64) {
 func (api *API) IsProposer() bool {
 	return api.hotstuff.core.IsProposer()
 }
+
+// CensorshipSuspects returns the number of times each leader was suspected of
+// leaving out transactions of the local pool
+func (api *API) CensorshipSuspects() map[common.Address]uint64 {
+	return api.hotstuff.CensorshipSuspects()
+}
+
+// DebugAPI exposes the consensus internals of the node for debugging
+type DebugAPI struct {
+	hotstuff *Backend
+}
+
+// HotstuffTimeline returns the timelines of the views of the given height, one per
+// round, as long as the height is among the 128 most recent ones seen by the node
+func (api *DebugAPI) HotstuffTimeline(height uint64) []*timeline.View {
+	return api.hotstuff.core.Timeline(height)
+}
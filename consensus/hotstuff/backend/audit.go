@@ -0,0 +1,203 @@
+package backend
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// AuditChain retrieves the canonical headers checked by Audit, e.g. a chain or a
+// reader over a chain database
+type AuditChain interface {
+	// GetHeaderByNumber retrieves the canonical header of a height, nil if missing
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// AuditIssue is an integrity violation found in a header
+type AuditIssue struct {
+	Number uint64
+	Hash   common.Hash
+	Reason string
+}
+
+func (i AuditIssue) String() string {
+	return fmt.Sprintf("block %d (%s): %s", i.Number, i.Hash.TerminalString(), i.Reason)
+}
+
+// AuditReport is the result of Audit
+type AuditReport struct {
+	From, To uint64 // Range of the audited heights
+	Headers  uint64 // Number of headers found and checked
+	Issues   []AuditIssue
+}
+
+// Audit re-verifies the canonical headers in [from, to] without running the
+// engine. Headers are checked against a snapshot of the validators which starts
+// with valSet, the configured set, and registers the BLS keys voted in by the
+// headers as they are audited. Every header must be signed by a validator of the
+// snapshot, record its validators, and carry a commit QC of its own height that
+// authenticates with the signer and certifies the header. Missing headers, broken
+// parent links, invalid key votes and QCs seen on an earlier header are reported
+// too.
+func Audit(chain AuditChain, signer hs.Signer, valSet hs.ValidatorSet, from, to uint64) *AuditReport {
+	if from == 0 {
+		from = 1 // the genesis block carries no seal
+	}
+	report := &AuditReport{From: from, To: to}
+
+	// the keys voted in before the range sign QCs of the range
+	var (
+		multi, _ = signer.(*snr.MultiSigner)
+		keys     = new(keySnapshot)
+	)
+	for number := uint64(1); number < from; number++ {
+		if header := chain.GetHeaderByNumber(number); header != nil {
+			auditKeyVote(keys, multi, signer, valSet, header)
+		}
+	}
+
+	// the certified proposal of the header before the range, which the first header
+	// of the range builds on
+	var (
+		parent   = chain.GetHeaderByNumber(from - 1)
+		proposed common.Hash
+		seen     = make(map[common.Hash]uint64)
+	)
+	if parent != nil {
+		if extra, err := types.ExtractHotstuffExtra(parent); err == nil {
+			if qc, err := decodeCommitQC(extra); err == nil {
+				proposed = qc.ProposedBlock
+			}
+		}
+	}
+
+	for number := from; number <= to; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			report.Issues = append(report.Issues, AuditIssue{Number: number, Reason: "missing header"})
+			parent, proposed = nil, common.Hash{}
+			continue
+		}
+		report.Headers++
+		hash := header.Hash()
+		issue := func(format string, args ...interface{}) {
+			report.Issues = append(report.Issues, AuditIssue{Number: number, Hash: hash, Reason: fmt.Sprintf(format, args...)})
+		}
+
+		if parent != nil && header.ParentHash != parent.Hash() {
+			issue("parent hash %s does not match block %d", header.ParentHash.TerminalString(), number-1)
+		}
+		parent = header
+
+		// ECDSA seal of the proposer
+		proposer, err := signer.RecoverSigner(header)
+		if err != nil {
+			issue("invalid proposer seal: %v", err)
+		} else if proposer != header.Coinbase {
+			issue("proposer seal of %s, but coinbase is %s", proposer.Hex(), header.Coinbase.Hex())
+		} else if _, val := valSet.GetByAddress(proposer); val == nil {
+			issue("proposer %s is not a validator", proposer.Hex())
+		}
+
+		extra, err := types.ExtractHotstuffExtra(header)
+		if err != nil {
+			issue("invalid extra data: %v", err)
+			proposed = common.Hash{}
+			continue
+		}
+
+		// commit QC. A QC of round 0 certifies the proposal built on the commit QC of
+		// the parent, after a view change the proposal extends the highQC collected by
+		// the new leader instead, which the headers do not record.
+		certified := proposed
+		proposed = common.Hash{}
+		if qc, err := decodeCommitQC(extra); err != nil {
+			issue("invalid commit QC: %v", err)
+		} else {
+			switch {
+			case qc.Code != hs.MsgTypeCommitVote:
+				issue("QC of %v, not of commit votes", qc.Code)
+			case qc.View.Height.Uint64() != number:
+				issue("QC of height %d", qc.View.Height.Uint64())
+			}
+			if prev, ok := seen[qc.ProposedBlock]; ok {
+				issue("QC replayed from block %d", prev)
+			} else {
+				seen[qc.ProposedBlock] = number
+			}
+			if qc.View.Round.Sign() == 0 && certified != (common.Hash{}) && qc.ProposedBlock != hs.RLPHash([]common.Hash{certified, hash}) {
+				issue("QC does not certify the block")
+			}
+			proposed = qc.ProposedBlock
+			if err := signer.AuthQC(qc); err != nil {
+				issue("QC signature invalid: %v", err)
+			}
+		}
+
+		// validator set recorded by the header, static unless managed by a validator
+		// contract, which the audit does not follow
+		if !sameMembers(valSet.AddressList(), extra.Validators) {
+			issue("validator set mismatch, have %d validators, recorded %d", valSet.Size(), len(extra.Validators))
+		}
+
+		// keys voted in by the header sign the QCs of the following ones
+		if err := auditKeyVote(keys, multi, signer, valSet, header); err != nil {
+			issue("invalid BLS key vote: %v", err)
+		}
+	}
+	return report
+}
+
+// auditKeyVote applies the BLS key vote of a header to the key snapshot, and
+// registers the key with the multi-signature scheme once it is voted in
+func auditKeyVote(keys *keySnapshot, multi *snr.MultiSigner, signer hs.Signer, valSet hs.ValidatorSet, header *types.Header) error {
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil || extra.KeyVote == nil {
+		return nil
+	}
+	if multi == nil {
+		return errInvalidKeyVote
+	}
+	if keys.registered(extra.KeyVote.Address) {
+		return errRegisteredKey
+	}
+	key := &snr.BLSPublicKey{
+		Address:   extra.KeyVote.Address,
+		PublicKey: extra.KeyVote.PublicKey,
+		Proof:     extra.KeyVote.Proof,
+	}
+	if err := multi.VerifyPublicKey(key); err != nil {
+		return err
+	}
+	voter, err := signer.RecoverSigner(header)
+	if err != nil {
+		return err
+	}
+	if keys.cast(voter, key, valSet) && !multi.HasPublicKey(key.Address) {
+		return multi.AddPublicKey(key)
+	}
+	return nil
+}
+
+func decodeCommitQC(extra *types.HotstuffExtra) (*hs.QuorumCert, error) {
+	var qc *hs.QuorumCert
+	if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+		return nil, err
+	}
+	if qc.View == nil || qc.View.Height == nil || qc.View.Round == nil {
+		return nil, hs.ErrInvalidMessage
+	}
+	return qc, nil
+}
+
+func validatorMap(vals []common.Address) map[common.Address]bool {
+	m := make(map[common.Address]bool, len(vals))
+	for _, val := range vals {
+		m[val] = true
+	}
+	return m
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	lru "github.com/hashicorp/golang-lru"
 )
@@ -37,15 +38,32 @@ type Backend struct {
 	hasBadBlock  func(db ethdb.Reader, hash common.Hash) bool
 	logger       log.Logger
 
-	valset         hs.ValidatorSet
+	valset         hs.ValidatorSet // Validator set of the current height, replaced when it changes
+	valsetMu       sync.RWMutex
 	recents        *lru.ARCCache // Snapshots for recent block to speed up reorgs
 	recentMessages *lru.ARCCache // the cache of peer's messages
 	knownMessages  *lru.ARCCache // the cache of self messages
+	commitRecords  *lru.ARCCache // the cache of commit records used by leader reputation
+
+	finalizedHeaders *lru.ARCCache // Headers whose commit QC has been verified
+	commitStatus     commitStatus  // Last commit reported by Status
+
+	censorship censorshipMonitor // Transactions left out by proposals, and suspected leaders
+
+	observers *lru.ARCCache // Observer peers subscribed to decided blocks
+
+	validatorContract *validatorContract // Source of the validator set, if managed by a contract
+	epochsMu          sync.Mutex
+	epochs            *validatorEpochs // Index of the validator snapshots, loaded on first use
+
+	qcCache  *qcCache  // Signer of the core, authenticating each QC once
+	verifier *verifier // Workers checking received messages while the engine runs
 
 	// The channels for hotstuff engine notifications
 	sealMu            sync.Mutex
 	commitCh          chan *types.Block
 	proposedBlockHash common.Hash
+	heldEmpty         int32 // 1 while an empty block waits for the empty block period, accessed atomically
 	coreStarted       bool
 	sigMu             sync.RWMutex // Protects the address fields
 	consenMu          sync.Mutex   // Ensure a round can only start after the last one has finished
@@ -54,10 +72,22 @@ type Backend struct {
 	// event subscription for ChainHeadEvent event
 	broadcaster consensus.Broadcaster
 
-	executeFeed event.Feed // event subscription for executed state
-	eventMux    *event.TypeMux
+	executeFeed   event.Feed // event subscription for executed state
+	speculateFeed event.Feed // event subscription for locked blocks the miner builds on
+	eventMux      *event.TypeMux
 
-	proposals map[common.Address]bool // Current list of proposals we are pushing
+	proposals    map[common.Address]bool              // Current list of proposals we are pushing
+	keyProposals map[common.Address]*snr.BLSPublicKey // BLS public keys the node votes to register
+	keySnapshots *lru.ARCCache                        // BLS key votes after recent blocks
+
+	bundlesMu sync.Mutex
+	bundles   map[bundleKey]*pendingBundle // Votes of the local subtree under the Tree topology
+
+	txPool           consensus.TxPool        // Source of the transactions of compact proposals
+	inserter         consensus.ChainInserter // Chain decided blocks are inserted into by observers
+	servedProposals  *lru.ARCCache           // Blocks whose transactions are served to replicas of compact proposals
+	pendingMu        sync.Mutex
+	pendingProposals map[common.Hash]*pendingProposal // Compact proposals waiting for missing transactions
 }
 
 func New(
@@ -66,12 +96,28 @@ func New(
 	db ethdb.Database,
 	valset hs.ValidatorSet,
 	blsInfo *types.BLSInfo,
+) *Backend {
+	signer := snr.NewSigner(privateKey, byte(hs.MsgTypePrepareVote), blsInfo)
+	return NewWithSigner(config, db, valset, signer)
+}
+
+// NewWithSigner creates a backend using the given signing scheme, e.g. a
+// snr.MultiSigner
+func NewWithSigner(
+	config *hs.Config,
+	db ethdb.Database,
+	valset hs.ValidatorSet,
+	signer hs.Signer,
 ) *Backend {
 	recents, _ := lru.NewARC(inmemorySnapshots)
+	keySnapshots, _ := lru.NewARC(inmemorySnapshots)
 	recentMessages, _ := lru.NewARC(inmemoryPeers)
 	knownMessages, _ := lru.NewARC(inmemoryMessages)
+	commitRecords, _ := lru.NewARC(inmemoryCommitRecords)
+	servedProposals, _ := lru.NewARC(inmemoryProposals)
+	finalizedHeaders, _ := lru.NewARC(inmemoryFinalized)
+	observers, _ := lru.NewARC(inmemoryObservers)
 
-	signer := snr.NewSigner(privateKey, byte(hs.MsgTypePrepareVote), blsInfo)
 	backend := &Backend{
 		config:         config,
 		db:             db,
@@ -83,11 +129,28 @@ func New(
 		signer:         signer,
 		recentMessages: recentMessages,
 		knownMessages:  knownMessages,
+		commitRecords:  commitRecords,
 		recents:        recents,
 		proposals:      make(map[common.Address]bool),
+		keyProposals:   make(map[common.Address]*snr.BLSPublicKey),
+		keySnapshots:   keySnapshots,
+		bundles:        make(map[bundleKey]*pendingBundle),
+
+		finalizedHeaders: finalizedHeaders,
+		observers:        observers,
+		servedProposals:  servedProposals,
+		pendingProposals: make(map[common.Hash]*pendingProposal),
+
+		validatorContract: newValidatorContract(config),
+		qcCache:           newQCCache(signer),
 	}
 
-	backend.core = hsc.New(backend, config, signer, db, valset)
+	// QCs are verified with the validator snapshot of their height
+	if multi := backend.multiSigner(); multi != nil && backend.validatorContract != nil {
+		multi.SetValidatorHistory(backend.validatorsOf)
+	}
+
+	backend.core = hsc.New(backend, config, backend.qcCache, db, valset)
 	return backend
 }
 
@@ -108,21 +171,31 @@ func (s *Backend) EventMux() *event.TypeMux {
 
 // Broadcast implements hs.Backend.Broadcast
 func (s *Backend) Broadcast(valSet hs.ValidatorSet, payload []byte) error {
-	// send to others
-	if err := s.Gossip(valSet, payload); err != nil {
+	msg, code, err := decodeMsgCode(payload)
+	if err != nil {
+		return err
+	}
+	// send to others, only to the children of the leader under the Tree topology
+	if s.treeRouted(msg) {
+		s.knownMessages.Add(hs.RLPHash(payload), true)
+		s.relayTree(valSet, s.Address(), code, payload)
+	} else if err := s.Gossip(valSet, payload); err != nil {
 		return err
 	}
 	// send to self
-	msg := hs.MessageEvent{
+	go s.EventMux().Post(hs.MessageEvent{
 		Src:     s.Address(),
 		Payload: payload,
-	}
-	go s.EventMux().Post(msg)
+	})
 	return nil
 }
 
 // Broadcast implements hs.Backend.Gossip
 func (s *Backend) Gossip(valSet hs.ValidatorSet, payload []byte) error {
+	_, code, err := decodeMsgCode(payload)
+	if err != nil {
+		return err
+	}
 	hash := hs.RLPHash(payload)
 	s.knownMessages.Add(hash, true)
 
@@ -132,8 +205,18 @@ func (s *Backend) Gossip(valSet hs.ValidatorSet, payload []byte) error {
 			targets[val.Address()] = true
 		}
 	}
+	s.gossipTo(targets, code, payload)
+	return nil
+}
+
+// gossipTo sends a message to the given peers which have not seen it yet
+func (s *Backend) gossipTo(targets map[common.Address]bool, code uint64, payload []byte) {
+	hash := hs.RLPHash(payload)
 	if s.broadcaster != nil && len(targets) > 0 {
 		ps := s.broadcaster.FindPeers(targets)
+		if len(ps) > 0 {
+			code, payload = s.compactTo(code, payload)
+		}
 		for addr, p := range ps {
 			ms, ok := s.recentMessages.Get(addr)
 			var m *lru.ARCCache
@@ -149,14 +232,31 @@ func (s *Backend) Gossip(valSet hs.ValidatorSet, payload []byte) error {
 
 			m.Add(hash, true)
 			s.recentMessages.Add(addr, m)
-			go p.SendConsensus(hotstuffMsg, payload)
+			go p.SendConsensus(code, payload)
 		}
 	}
-	return nil
+}
+
+// compactTo returns the message code and payload to send, replacing the block of a
+// Prepare message with its transaction hashes if compact proposals are enabled
+func (s *Backend) compactTo(code uint64, payload []byte) (uint64, []byte) {
+	if !s.config.CompactProposal || code != uint64(hs.MsgTypePrepare) {
+		return code, payload
+	}
+	compact, err := s.compactPayload(payload)
+	if err != nil {
+		s.logger.Debug("Failed to compact proposal, sending full block", "err", err)
+		return code, payload
+	}
+	return compactPrepareMsg, compact
 }
 
 // Unicast implements hs.Backend.Unicast
 func (s *Backend) Unicast(valSet hs.ValidatorSet, payload []byte) error {
+	vote, code, err := decodeMsgCode(payload)
+	if err != nil {
+		return err
+	}
 	msg := hs.MessageEvent{Src: s.Address(), Payload: payload}
 	leader := valSet.GetProposer()
 	target := leader.Address()
@@ -169,6 +269,16 @@ func (s *Backend) Unicast(valSet hs.ValidatorSet, payload []byte) error {
 		return nil
 	}
 
+	// send votes up the tree rooted at the leader under the Tree topology
+	if s.treeRouted(vote) && isVote(vote.Code) {
+		vote.Address = s.Address()
+		if tv, err := s.newTreeVote(vote, payload); err != nil {
+			s.logger.Warn("Failed to add vote to the tree", "msgCode", vote.Code, "err", err)
+		} else if s.addTreeVote(target, tv) {
+			return nil
+		}
+	}
+
 	// send to other peer
 	if s.broadcaster != nil {
 		if p := s.broadcaster.FindPeer(target); p != nil {
@@ -185,7 +295,7 @@ func (s *Backend) Unicast(valSet hs.ValidatorSet, payload []byte) error {
 			m.Add(hash, true)
 			s.recentMessages.Add(target, m)
 			go func() {
-				if err := p.SendConsensus(hotstuffMsg, payload); err != nil {
+				if err := p.SendConsensus(code, payload); err != nil {
 					s.logger.Error("unicast message failed", "err", err)
 				}
 			}()
@@ -217,13 +327,38 @@ func (s *Backend) SealBlock(block *types.Block, commitQC *hs.QuorumCert) (*types
 	return block.WithSeal(h), nil
 }
 
+// Speculate sends a copy of the state of the locked block to the miner, without
+// waiting for the miner. The copy is taken on the core goroutine, before Commit
+// writes the state. Blocks which may
+// change a validator set managed by a contract are not built on, as the extra-data of
+// the next block depends on the set.
+func (s *Backend) Speculate(executed *consensus.ExecutedBlock) {
+	if executed == nil || executed.Block == nil || executed.State == nil || s.validatorContract != nil {
+		return
+	}
+	s.logger.Trace("Speculating on locked block", "hash", executed.Block.Hash(), "number", executed.Block.Number())
+	parent := consensus.SpeculativeParent{Block: executed.Block, State: executed.State.Copy()}
+	go s.speculateFeed.Send(parent)
+}
+
 func (s *Backend) Commit(executed *consensus.ExecutedBlock) error {
 	block := executed.Block
 
 	if executed == nil || executed.Block == nil {
 		return fmt.Errorf("invalid executed block")
 	}
+
+	// replicas write the state executed during Prepare rather than importing the block
+	if s.proposedBlockHash != block.Hash() && executed.State != nil {
+		if writer, ok := s.chain.(consensus.ChainWriter); ok {
+			if err := writer.WriteExecutedBlock(block, executed.Receipts, executed.Logs, executed.State); err != nil {
+				s.logger.Debug("Failed to write executed block, importing it", "hash", block.Hash(), "number", block.NumberU64(), "err", err)
+			}
+		}
+	}
 	s.executeFeed.Send(*executed)
+	s.recordCommit(block.Header())
+	s.forwardDecided(block)
 
 	s.logger.Info("Committed", "address", s.Address(), "hash", block.Hash(), "number", block.Number().Uint64())
 
@@ -238,6 +373,7 @@ func (s *Backend) Commit(executed *consensus.ExecutedBlock) error {
 		s.commitCh <- block
 		return nil
 	}
+	// the block is announced to the peers either way, and imported unless written
 	if s.broadcaster != nil {
 		s.broadcaster.Enqueue(fetcherID, block)
 	}
@@ -266,7 +402,7 @@ func (s *Backend) Verify(block *types.Block) (time.Duration, error) {
 	if err == nil {
 		return 0, nil
 	} else if err == consensus.ErrFutureBlock {
-		return time.Unix(int64(block.Header().Time), 0).Sub(now()), consensus.ErrFutureBlock
+		return types.HotstuffHeaderTime(block.Header()).Sub(now()), consensus.ErrFutureBlock
 	}
 	return 0, err
 }
@@ -307,6 +443,59 @@ func (s *Backend) GetProposer(number uint64) common.Address {
 	return common.Address{}
 }
 
+// CommitHistory implements hs.Backend.CommitHistory
+func (s *Backend) CommitHistory(number uint64, window uint64) []hs.CommitRecord {
+	if s.chain == nil || window == 0 {
+		return nil
+	}
+
+	// genesis block is not committed by any proposer
+	first := uint64(1)
+	if number >= window {
+		first = number - window + 1
+	}
+	records := make([]hs.CommitRecord, 0, window)
+	for n := first; n <= number; n++ {
+		header := s.chain.GetHeaderByNumber(n)
+		if header == nil {
+			continue
+		}
+		record, err := s.commitRecord(header)
+		if err != nil {
+			s.logger.Trace("Failed to read commit record", "number", n, "err", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func (s *Backend) commitRecord(header *types.Header) (hs.CommitRecord, error) {
+	if record, ok := s.commitRecords.Get(header.Hash()); ok {
+		return record.(hs.CommitRecord), nil
+	}
+
+	proposer, err := s.Author(header)
+	if err != nil {
+		return hs.CommitRecord{}, err
+	}
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil {
+		return hs.CommitRecord{}, err
+	}
+	var commitQC *hs.QuorumCert
+	if err := rlp.DecodeBytes(extra.EncodedQC, &commitQC); err != nil {
+		return hs.CommitRecord{}, err
+	}
+	record := hs.CommitRecord{
+		Number:   header.Number.Uint64(),
+		Proposer: proposer,
+		Round:    commitQC.View.Round.Uint64(),
+	}
+	s.commitRecords.Add(header.Hash(), record)
+	return record, nil
+}
+
 func (s *Backend) HasBadProposal(hash common.Hash) bool {
 	if s.hasBadBlock == nil {
 		return false
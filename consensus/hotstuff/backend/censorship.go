@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// censorshipMonitor counts, for the next transaction of every account in the local
+// pool, the proposals with spare gas which left it out
+type censorshipMonitor struct {
+	mu       sync.Mutex
+	omitted  map[common.Hash]uint64    // Proposals which left out each pending transaction
+	checked  common.Hash               // Last checked block, a re-proposed block counts once
+	suspects map[common.Address]uint64 // Suspicions raised against each leader
+}
+
+// SuspectCensorship implements hs.Backend.SuspectCensorship. Only the next transaction
+// of an account is executable on top of the parent, and accounts with transactions in
+// the block are skipped since their pool nonces are about to move. Once a leader is
+// suspected the counts start over, so that the leader of the next view is not
+// suspected for the same transactions. Own proposals are counted as well, so that
+// every node holds the same counts, but the node never suspects itself.
+func (s *Backend) SuspectCensorship(block *types.Block, proposer common.Address) bool {
+	if s.config.CensorshipViews == 0 || s.txPool == nil {
+		return false
+	}
+	pending, err := s.txPool.Pending()
+	if err != nil {
+		s.logger.Warn("Failed to read pending transactions", "err", err)
+		return false
+	}
+
+	var (
+		signer  = types.MakeSigner(s.chain.Config(), block.Number())
+		spare   = block.GasLimit() - block.GasUsed()
+		senders = make(map[common.Address]bool)
+	)
+	for _, tx := range block.Transactions() {
+		if from, err := types.Sender(signer, tx); err == nil {
+			senders[from] = true
+		}
+	}
+
+	m := &s.censorship
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.checked == block.Hash() {
+		return false
+	}
+	m.checked = block.Hash()
+
+	var (
+		omitted   = make(map[common.Hash]uint64)
+		suspected = false
+	)
+	for from, txs := range pending {
+		if len(txs) == 0 || senders[from] {
+			continue
+		}
+		tx := txs[0]
+		count := m.omitted[tx.Hash()]
+		if tx.Gas() <= spare {
+			count++
+		}
+		if count > s.config.CensorshipViews {
+			suspected = true
+		}
+		omitted[tx.Hash()] = count
+	}
+	m.omitted = omitted
+	if !suspected {
+		return false
+	}
+	m.omitted = make(map[common.Hash]uint64)
+	if proposer == s.Address() {
+		return false
+	}
+	if m.suspects == nil {
+		m.suspects = make(map[common.Address]uint64)
+	}
+	m.suspects[proposer]++
+	return true
+}
+
+// CensorshipSuspects returns the number of suspicions of censorship raised against
+// each leader since the node started
+func (s *Backend) CensorshipSuspects() map[common.Address]uint64 {
+	m := &s.censorship
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	suspects := make(map[common.Address]uint64, len(m.suspects))
+	for addr, count := range m.suspects {
+		suspects[addr] = count
+	}
+	return suspects
+}
@@ -0,0 +1,265 @@
+package backend
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// inmemoryProposals is the number of own or relayed proposals kept to serve the
+	// transactions missing from the pool of other replicas
+	inmemoryProposals = 16
+
+	// pendingProposalHeights is the number of heights for which incomplete compact
+	// proposals are kept
+	pendingProposalHeights = 2
+)
+
+var (
+	errInvalidProposalTxs  = errors.New("invalid compact proposal transactions")
+	errUnrequestedTxs      = errors.New("compact proposal transactions not requested from peer")
+	errUncompactableBlocks = errors.New("block with uncles can not be compacted")
+)
+
+// compactPrepare is a Prepare message whose block only carries transaction hashes.
+// Replicas rebuild the block from their transaction pool and re-encode the original
+// message, whose signature still covers the full block.
+type compactPrepare struct {
+	View      *hs.View
+	Parent    common.Hash // Parent ProposedBlock hash
+	Header    *types.Header
+	TxHashes  []common.Hash
+	QC        *hs.QuorumCert
+	Address   common.Address
+	Signature []byte
+}
+
+// getProposalTxsData requests the transactions of a proposal by index
+type getProposalTxsData struct {
+	Hash    common.Hash // Block hash
+	Indexes []uint64
+}
+
+// proposalTxsData answers a getProposalTxsData, in the order of the requested indexes
+type proposalTxsData struct {
+	Hash common.Hash
+	Txs  []*types.Transaction
+}
+
+// pendingProposal is a compact proposal waiting for its missing transactions
+type pendingProposal struct {
+	compact *compactPrepare
+	peer    common.Address // Peer the missing transactions are requested from
+	txs     []*types.Transaction
+	missing []uint64
+}
+
+// compactPayload turns a Prepare message into a compactPrepare. The block is kept,
+// so that the transactions can be served to replicas which miss them.
+func (s *Backend) compactPayload(payload []byte) ([]byte, error) {
+	msg := new(hs.Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil {
+		return nil, err
+	}
+	var subject *hs.PackagedQC
+	if err := msg.Decode(&subject); err != nil {
+		return nil, err
+	}
+	if subject.ProposedBlock == nil || subject.ProposedBlock.Block == nil {
+		return nil, hs.ErrInvalidProposal
+	}
+	block := subject.ProposedBlock.Block
+	if len(block.Uncles()) > 0 {
+		return nil, errUncompactableBlocks
+	}
+
+	hashes := make([]common.Hash, len(block.Transactions()))
+	for i, tx := range block.Transactions() {
+		hashes[i] = tx.Hash()
+	}
+	s.servedProposals.Add(block.Hash(), block)
+
+	return rlp.EncodeToBytes(&compactPrepare{
+		View:      msg.View,
+		Parent:    subject.ProposedBlock.Parent,
+		Header:    block.Header(),
+		TxHashes:  hashes,
+		QC:        subject.QC,
+		Address:   msg.Address,
+		Signature: msg.Signature,
+	})
+}
+
+// expand rebuilds the original Prepare message from the compact proposal and its
+// transactions
+func (c *compactPrepare) expand(txs []*types.Transaction) ([]byte, error) {
+	block := types.NewBlockWithHeader(c.Header).WithBody(txs, nil)
+	node := hs.NewProposedBlock(c.Parent, block)
+	subject, err := hs.Encode(hs.NewPackagedQC(node, c.QC))
+	if err != nil {
+		return nil, err
+	}
+	return rlp.EncodeToBytes(&hs.Message{
+		Code:      hs.MsgTypePrepare,
+		View:      c.View,
+		Msg:       subject,
+		Address:   c.Address,
+		Signature: c.Signature,
+	})
+}
+
+// handleCompactPrepare rebuilds a compact proposal from the transaction pool, and
+// requests the missing transactions from the peer which sent it
+func (s *Backend) handleCompactPrepare(addr common.Address, data []byte) error {
+	compact := new(compactPrepare)
+	if err := rlp.DecodeBytes(data, compact); err != nil {
+		return hs.ErrDecodeFailed
+	}
+	if compact.View == nil || compact.Header == nil {
+		return hs.ErrInvalidMessage
+	}
+
+	pending := &pendingProposal{
+		compact: compact,
+		peer:    addr,
+		txs:     make([]*types.Transaction, len(compact.TxHashes)),
+	}
+	for i, hash := range compact.TxHashes {
+		var tx *types.Transaction
+		if s.txPool != nil {
+			tx = s.txPool.Get(hash)
+		}
+		if tx == nil {
+			pending.missing = append(pending.missing, uint64(i))
+		}
+		pending.txs[i] = tx
+	}
+	if len(pending.missing) == 0 {
+		return s.handleExpandedPrepare(addr, pending)
+	}
+
+	hash := compact.Header.Hash()
+	number := compact.Header.Number.Uint64()
+	s.pendingMu.Lock()
+	for h, p := range s.pendingProposals {
+		if p.compact.Header.Number.Uint64()+pendingProposalHeights < number {
+			delete(s.pendingProposals, h)
+		}
+	}
+	s.pendingProposals[hash] = pending
+	s.pendingMu.Unlock()
+
+	if s.broadcaster == nil {
+		return nil
+	}
+	p := s.broadcaster.FindPeer(addr)
+	if p == nil {
+		return nil
+	}
+	s.logger.Trace("Fetching proposal transactions", "hash", hash, "number", number, "missing", len(pending.missing), "from", addr)
+	req, err := rlp.EncodeToBytes(&getProposalTxsData{Hash: hash, Indexes: pending.missing})
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := p.SendConsensus(getProposalTxsMsg, req); err != nil {
+			s.logger.Error("request proposal transactions failed", "err", err)
+		}
+	}()
+	return nil
+}
+
+// handleGetProposalTxs serves the transactions of a proposal sent or relayed by
+// this node
+func (s *Backend) handleGetProposalTxs(addr common.Address, data []byte) error {
+	var req getProposalTxsData
+	if err := rlp.DecodeBytes(data, &req); err != nil {
+		return hs.ErrDecodeFailed
+	}
+	cached, ok := s.servedProposals.Get(req.Hash)
+	if !ok {
+		s.logger.Debug("Unknown proposal requested", "hash", req.Hash, "from", addr)
+		return nil
+	}
+	txs := cached.(*types.Block).Transactions()
+
+	res := &proposalTxsData{Hash: req.Hash, Txs: make([]*types.Transaction, 0, len(req.Indexes))}
+	for _, index := range req.Indexes {
+		if index >= uint64(len(txs)) {
+			return errInvalidProposalTxs
+		}
+		res.Txs = append(res.Txs, txs[index])
+	}
+	if s.broadcaster == nil {
+		return nil
+	}
+	p := s.broadcaster.FindPeer(addr)
+	if p == nil {
+		return nil
+	}
+	payload, err := rlp.EncodeToBytes(res)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := p.SendConsensus(proposalTxsMsg, payload); err != nil {
+			s.logger.Error("send proposal transactions failed", "err", err)
+		}
+	}()
+	return nil
+}
+
+// handleProposalTxs completes a pending compact proposal with the fetched
+// transactions
+func (s *Backend) handleProposalTxs(addr common.Address, data []byte) error {
+	var res proposalTxsData
+	if err := rlp.DecodeBytes(data, &res); err != nil {
+		return hs.ErrDecodeFailed
+	}
+	pending, err := s.takePendingProposal(addr, &res)
+	if pending == nil || err != nil {
+		return err
+	}
+	for i, index := range pending.missing {
+		pending.txs[index] = res.Txs[i]
+	}
+	return s.handleExpandedPrepare(addr, pending)
+}
+
+// takePendingProposal removes and returns the pending proposal completed by res, or
+// nil if none is pending. Only the peer the transactions were requested from may
+// answer, and the proposal stays pending until it answers with the missing ones.
+func (s *Backend) takePendingProposal(addr common.Address, res *proposalTxsData) (*pendingProposal, error) {
+	s.pendingMu.Lock()
+	defer s.pendingMu.Unlock()
+
+	pending, ok := s.pendingProposals[res.Hash]
+	if !ok {
+		return nil, nil
+	}
+	if pending.peer != addr {
+		return nil, errUnrequestedTxs
+	}
+	if len(res.Txs) != len(pending.missing) {
+		return nil, errInvalidProposalTxs
+	}
+	for i, index := range pending.missing {
+		if res.Txs[i] == nil || res.Txs[i].Hash() != pending.compact.TxHashes[index] {
+			return nil, errInvalidProposalTxs
+		}
+	}
+	delete(s.pendingProposals, res.Hash)
+	return pending, nil
+}
+
+func (s *Backend) handleExpandedPrepare(addr common.Address, pending *pendingProposal) error {
+	payload, err := pending.compact.expand(pending.txs)
+	if err != nil {
+		return err
+	}
+	return s.handleConsensusPayload(addr, uint64(hs.MsgTypePrepare), payload)
+}
@@ -0,0 +1,11 @@
+// Interface for contracts used to select HotStuff validators
+
+pragma solidity >=0.6.0;
+
+interface HotStuffValidatorContractInterface {
+    function getValidators() external view returns (address[] memory);
+
+    // BLS public keys and proofs of possession of the validators, in the order of
+    // getValidators(). Only read with the MultiSig signature scheme.
+    function getBLSPublicKeys() external view returns (bytes[] memory publicKeys, bytes[] memory proofs);
+}
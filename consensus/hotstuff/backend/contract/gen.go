@@ -0,0 +1,10 @@
+// this is to generate go binding for the HotStuff validators smart contract
+//
+// Require:
+// 1. solc 0.6.0+
+// 2. abigen (make all from root)
+//go:generate solc --abi --bin -o . --overwrite ./HotStuffValidatorContractInterface.sol
+//go:generate abigen -pkg contract -abi  ./HotStuffValidatorContractInterface.abi            -bin  ./HotStuffValidatorContractInterface.bin            -type  HotStuffValidatorContractInterface  -out ./hotstuff_validator_contract_interface.go
+//go:generate rm HotStuffValidatorContractInterface.abi HotStuffValidatorContractInterface.bin
+
+package contract
@@ -0,0 +1,250 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contract
+
+import (
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+)
+
+// HotStuffValidatorContractInterfaceABI is the input ABI used to generate the binding from.
+const HotStuffValidatorContractInterfaceABI = "[{\"inputs\":[],\"name\":\"getBLSPublicKeys\",\"outputs\":[{\"internalType\":\"bytes[]\",\"name\":\"publicKeys\",\"type\":\"bytes[]\"},{\"internalType\":\"bytes[]\",\"name\":\"proofs\",\"type\":\"bytes[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getValidators\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+
+var HotStuffValidatorContractInterfaceParsedABI, _ = abi.JSON(strings.NewReader(HotStuffValidatorContractInterfaceABI))
+
+// HotStuffValidatorContractInterface is an auto generated Go binding around an Ethereum contract.
+type HotStuffValidatorContractInterface struct {
+	HotStuffValidatorContractInterfaceCaller     // Read-only binding to the contract
+	HotStuffValidatorContractInterfaceTransactor // Write-only binding to the contract
+	HotStuffValidatorContractInterfaceFilterer   // Log filterer for contract events
+}
+
+// HotStuffValidatorContractInterfaceCaller is an auto generated read-only Go binding around an Ethereum contract.
+type HotStuffValidatorContractInterfaceCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// HotStuffValidatorContractInterfaceTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type HotStuffValidatorContractInterfaceTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// HotStuffValidatorContractInterfaceFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type HotStuffValidatorContractInterfaceFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// HotStuffValidatorContractInterfaceSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type HotStuffValidatorContractInterfaceSession struct {
+	Contract     *HotStuffValidatorContractInterface // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts                       // Call options to use throughout this session
+	TransactOpts bind.TransactOpts                   // Transaction auth options to use throughout this session
+}
+
+// HotStuffValidatorContractInterfaceCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type HotStuffValidatorContractInterfaceCallerSession struct {
+	Contract *HotStuffValidatorContractInterfaceCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                             // Call options to use throughout this session
+}
+
+// HotStuffValidatorContractInterfaceTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type HotStuffValidatorContractInterfaceTransactorSession struct {
+	Contract     *HotStuffValidatorContractInterfaceTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                             // Transaction auth options to use throughout this session
+}
+
+// HotStuffValidatorContractInterfaceRaw is an auto generated low-level Go binding around an Ethereum contract.
+type HotStuffValidatorContractInterfaceRaw struct {
+	Contract *HotStuffValidatorContractInterface // Generic contract binding to access the raw methods on
+}
+
+// HotStuffValidatorContractInterfaceCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type HotStuffValidatorContractInterfaceCallerRaw struct {
+	Contract *HotStuffValidatorContractInterfaceCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// HotStuffValidatorContractInterfaceTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type HotStuffValidatorContractInterfaceTransactorRaw struct {
+	Contract *HotStuffValidatorContractInterfaceTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewHotStuffValidatorContractInterface creates a new instance of HotStuffValidatorContractInterface, bound to a specific deployed contract.
+func NewHotStuffValidatorContractInterface(address common.Address, backend bind.ContractBackend) (*HotStuffValidatorContractInterface, error) {
+	contract, err := bindHotStuffValidatorContractInterface(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &HotStuffValidatorContractInterface{HotStuffValidatorContractInterfaceCaller: HotStuffValidatorContractInterfaceCaller{contract: contract}, HotStuffValidatorContractInterfaceTransactor: HotStuffValidatorContractInterfaceTransactor{contract: contract}, HotStuffValidatorContractInterfaceFilterer: HotStuffValidatorContractInterfaceFilterer{contract: contract}}, nil
+}
+
+// NewHotStuffValidatorContractInterfaceCaller creates a new read-only instance of HotStuffValidatorContractInterface, bound to a specific deployed contract.
+func NewHotStuffValidatorContractInterfaceCaller(address common.Address, caller bind.ContractCaller) (*HotStuffValidatorContractInterfaceCaller, error) {
+	contract, err := bindHotStuffValidatorContractInterface(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &HotStuffValidatorContractInterfaceCaller{contract: contract}, nil
+}
+
+// NewHotStuffValidatorContractInterfaceTransactor creates a new write-only instance of HotStuffValidatorContractInterface, bound to a specific deployed contract.
+func NewHotStuffValidatorContractInterfaceTransactor(address common.Address, transactor bind.ContractTransactor) (*HotStuffValidatorContractInterfaceTransactor, error) {
+	contract, err := bindHotStuffValidatorContractInterface(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &HotStuffValidatorContractInterfaceTransactor{contract: contract}, nil
+}
+
+// NewHotStuffValidatorContractInterfaceFilterer creates a new log filterer instance of HotStuffValidatorContractInterface, bound to a specific deployed contract.
+func NewHotStuffValidatorContractInterfaceFilterer(address common.Address, filterer bind.ContractFilterer) (*HotStuffValidatorContractInterfaceFilterer, error) {
+	contract, err := bindHotStuffValidatorContractInterface(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &HotStuffValidatorContractInterfaceFilterer{contract: contract}, nil
+}
+
+// bindHotStuffValidatorContractInterface binds a generic wrapper to an already deployed contract.
+func bindHotStuffValidatorContractInterface(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(HotStuffValidatorContractInterfaceABI))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _HotStuffValidatorContractInterface.Contract.HotStuffValidatorContractInterfaceCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _HotStuffValidatorContractInterface.Contract.HotStuffValidatorContractInterfaceTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _HotStuffValidatorContractInterface.Contract.HotStuffValidatorContractInterfaceTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _HotStuffValidatorContractInterface.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _HotStuffValidatorContractInterface.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _HotStuffValidatorContractInterface.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetBLSPublicKeys is a free data retrieval call binding the contract method 0xe27276b9.
+//
+// Solidity: function getBLSPublicKeys() view returns(bytes[] publicKeys, bytes[] proofs)
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceCaller) GetBLSPublicKeys(opts *bind.CallOpts) (struct {
+	PublicKeys [][]byte
+	Proofs     [][]byte
+}, error) {
+	var out []interface{}
+	err := _HotStuffValidatorContractInterface.contract.Call(opts, &out, "getBLSPublicKeys")
+
+	outstruct := new(struct {
+		PublicKeys [][]byte
+		Proofs     [][]byte
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.PublicKeys = *abi.ConvertType(out[0], new([][]byte)).(*[][]byte)
+	outstruct.Proofs = *abi.ConvertType(out[1], new([][]byte)).(*[][]byte)
+
+	return *outstruct, err
+
+}
+
+// GetBLSPublicKeys is a free data retrieval call binding the contract method 0xe27276b9.
+//
+// Solidity: function getBLSPublicKeys() view returns(bytes[] publicKeys, bytes[] proofs)
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceSession) GetBLSPublicKeys() (struct {
+	PublicKeys [][]byte
+	Proofs     [][]byte
+}, error) {
+	return _HotStuffValidatorContractInterface.Contract.GetBLSPublicKeys(&_HotStuffValidatorContractInterface.CallOpts)
+}
+
+// GetBLSPublicKeys is a free data retrieval call binding the contract method 0xe27276b9.
+//
+// Solidity: function getBLSPublicKeys() view returns(bytes[] publicKeys, bytes[] proofs)
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceCallerSession) GetBLSPublicKeys() (struct {
+	PublicKeys [][]byte
+	Proofs     [][]byte
+}, error) {
+	return _HotStuffValidatorContractInterface.Contract.GetBLSPublicKeys(&_HotStuffValidatorContractInterface.CallOpts)
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceCaller) GetValidators(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _HotStuffValidatorContractInterface.contract.Call(opts, &out, "getValidators")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceSession) GetValidators() ([]common.Address, error) {
+	return _HotStuffValidatorContractInterface.Contract.GetValidators(&_HotStuffValidatorContractInterface.CallOpts)
+}
+
+// GetValidators is a free data retrieval call binding the contract method 0xb7ab4db5.
+//
+// Solidity: function getValidators() view returns(address[])
+func (_HotStuffValidatorContractInterface *HotStuffValidatorContractInterfaceCallerSession) GetValidators() ([]common.Address, error) {
+	return _HotStuffValidatorContractInterface.Contract.GetValidators(&_HotStuffValidatorContractInterface.CallOpts)
+}
@@ -1,7 +1,9 @@
 package backend
 
 import (
+	"io"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -18,6 +20,8 @@ const (
 	inmemorySnapshots = 128 // Number of recent vote snapshots to keep in memory
 	inmemoryPeers     = 1000
 	inmemoryMessages  = 1024
+
+	inmemoryCommitRecords = 1024 // Number of recent commit records to keep in memory
 )
 
 // HotStuff protocol constants.
@@ -33,7 +37,7 @@ func (s *Backend) Author(header *types.Header) (common.Address, error) {
 }
 
 func (s *Backend) VerifyHeader(chain consensus.ChainHeaderReader, header *types.Header, seal bool) error {
-	return s.verifyHeader(chain, header, nil, seal)
+	return s.verifyHeader(chain, header, nil, seal, nil)
 }
 
 func (s *Backend) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*types.Header, seals []bool) (chan<- struct{}, <-chan error) {
@@ -45,7 +49,7 @@ func (s *Backend) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*ty
 			if seals != nil && len(seals) > i {
 				seal = seals[i]
 			}
-			err := s.verifyHeader(chain, header, headers[:i], seal)
+			err := s.verifyHeader(chain, header, headers[:i], seal, abort)
 
 			select {
 			case <-abort:
@@ -79,35 +83,75 @@ func (s *Backend) Prepare(chain consensus.ChainHeaderReader, header *types.Heade
 	// use the same difficulty for all blocks
 	header.Difficulty = defaultDifficulty
 
-	// add validators in snapshot to extraData's validators section
-	valset := s.snap()
+	// add the validators governing the block to extraData's validators section
+	valset, err := s.validators(chain, parent, nil)
+	if err != nil {
+		return err
+	}
 	extra, err := s.signer.BuildPrepareExtra(header, valset)
 	if err != nil {
 		return err
 	}
 	header.Extra = extra
 
+	// vote for one of the BLS public keys the node proposes to register
+	if err := s.prepareKeyVote(chain, header, parent); err != nil {
+		return err
+	}
+
 	// set header's timestamp
-	header.Time = parent.Time + s.config.BlockPeriod
-	if header.Time < uint64(time.Now().Unix()) {
-		header.Time = uint64(time.Now().Unix())
+	timestamp := types.HotstuffHeaderTime(parent).Add(s.config.Period())
+	if now := time.Now(); timestamp.Before(now) {
+		timestamp = now
 	}
+	return s.setTimestamp(header, timestamp)
+}
 
-	return nil
+// setTimestamp sets the timestamp of the header, with the milliseconds in extra-data
+// for sub-second periods
+func (s *Backend) setTimestamp(header *types.Header, timestamp time.Time) error {
+	if s.config.BlockPeriodMs == 0 {
+		header.Time = uint64(timestamp.Unix())
+		return nil
+	}
+	timestamp = timestamp.Truncate(time.Millisecond)
+	header.Time = uint64(timestamp.Unix())
+	return header.SetHotstuffMillis(uint64(timestamp.Nanosecond() / int(time.Millisecond)))
 }
 
 func (s *Backend) Finalize(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header) {
-	// No block rewards in HotStuff, so the state remains as is and uncles are dropped
+	// Credit the block reward, if any, and drop the uncles. Imported headers were
+	// checked to resolve their beneficiaries.
+	if err := s.accumulateRewards(chain, state, header); err != nil {
+		s.logger.Error("Failed to accumulate block rewards", "number", header.Number, "err", err)
+	}
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 	header.UncleHash = nilUncleHash
 }
 
 func (s *Backend) FinalizeAndAssemble(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction,
 	uncles []*types.Header, receipts []*types.Receipt) (*types.Block, error) {
-	/// No block rewards in HotStuff, so the state remains as is and uncles are dropped
+	// Credit the block reward, if any, and drop the uncles
+	if err := s.accumulateRewards(chain, state, header); err != nil {
+		return nil, err
+	}
 	header.Root = state.IntermediateRoot(chain.Config().IsEIP158(header.Number))
 	header.UncleHash = nilUncleHash
 
+	// hold back an empty block until the empty block period passed, the miner seals
+	// a block with transactions in its place as soon as some arrive
+	if len(txs) == 0 && s.config.EmptyPeriod() > s.config.Period() {
+		parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+		if parent == nil {
+			return nil, consensus.ErrUnknownAncestor
+		}
+		if timestamp := types.HotstuffHeaderTime(parent).Add(s.config.EmptyPeriod()); types.HotstuffHeaderTime(header).Before(timestamp) {
+			if err := s.setTimestamp(header, timestamp); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// Assemble and return the final block for sealing
 	return types.NewBlock(header, txs, nil, receipts, trie.NewStackTrie(nil)), nil
 }
@@ -117,45 +161,50 @@ func (s *Backend) Seal(chain consensus.ChainHeaderReader, block *types.Block, re
 	header := block.Header()
 	number := header.Number.Uint64()
 
-	// bail out if we're unauthorized to sign a block
-	snap := s.snap()
-	if _, v := snap.GetByAddress(s.Address()); v == nil {
-		return hs.ErrUnauthorized
-	}
-
 	parent := chain.GetHeader(header.ParentHash, number-1)
 	if parent == nil {
 		return consensus.ErrUnknownAncestor
 	}
 
+	// bail out if we're unauthorized to sign a block
+	snap, err := s.validators(chain, parent, nil)
+	if err != nil {
+		return err
+	}
+	if _, v := snap.GetByAddress(s.Address()); v == nil {
+		return hs.ErrUnauthorized
+	}
+
 	// sign the HotstuffExtra.Seal portion with ECDSA
 	if err = s.signer.SignerSeal(header); err != nil {
 		return err
 	}
 	block = block.WithSeal(header)
 
-	delay := time.Until(time.Unix(int64(block.Header().Time), 0))
-
-	s.logger.Trace("WorkerSealNewBlock", "address", s.Address(), "hash", block.Hash(), "number", block.Number(), "delay", delay.Seconds())
+	s.logger.Trace("WorkerSealNewBlock", "address", s.Address(), "hash", block.Hash(), "number", block.Number(), "delay", time.Until(types.HotstuffHeaderTime(header)).Seconds())
 
 	go func() {
-		// wait for the timestamp of header, use this to adjust the block period
+		// get the proposed block hash and clear it if the seal() is completed.
+		s.sealMu.Lock()
 		select {
-		case <-time.After(delay):
 		case <-stop:
+			s.sealMu.Unlock()
 			results <- nil
 			return
+		default:
 		}
-
-		// get the proposed block hash and clear it if the seal() is completed.
-		s.sealMu.Lock()
 		s.proposedBlockHash = block.Hash()
+		if len(block.Transactions()) == 0 && s.config.EmptyPeriod() > s.config.Period() {
+			atomic.StoreInt32(&s.heldEmpty, 1)
+		}
 
 		defer func() {
 			s.proposedBlockHash = common.Hash{}
+			atomic.StoreInt32(&s.heldEmpty, 0)
 			s.sealMu.Unlock()
 		}()
-		// post block into HotStuff engine
+		// post block into HotStuff engine right away, the leader schedules the proposal
+		// at the timestamp of header, which adjusts the block period.
 		go s.EventMux().Post(hs.RequestEvent{
 			Block: block,
 		})
@@ -178,6 +227,12 @@ func (s *Backend) Seal(chain consensus.ChainHeaderReader, block *types.Block, re
 	return nil
 }
 
+// HoldsEmptyBlock reports whether the block being sealed is an empty block held back
+// until the empty block period passed
+func (s *Backend) HoldsEmptyBlock() bool {
+	return atomic.LoadInt32(&s.heldEmpty) == 1
+}
+
 func (s *Backend) SealHash(header *types.Header) common.Hash {
 	return s.signer.HeaderHash(header)
 }
@@ -193,6 +248,10 @@ func (s *Backend) APIs(chain consensus.ChainHeaderReader) []rpc.API {
 		Version:   "1.0",
 		Service:   &API{chain: chain, hotstuff: s},
 		Public:    true,
+	}, {
+		Namespace: "debug",
+		Version:   "1.0",
+		Service:   &DebugAPI{hotstuff: s},
 	}}
 }
 
@@ -218,6 +277,7 @@ func (s *Backend) Start(chain consensus.ChainReader, currentBlock func() *types.
 	if err := s.core.Start(); err != nil {
 		return err
 	}
+	s.verifier = newVerifier(s, int(s.config.VerifyWorkers))
 
 	s.coreStarted = true
 	return nil
@@ -233,11 +293,16 @@ func (s *Backend) Stop() error {
 	if err := s.core.Stop(); err != nil {
 		return err
 	}
+	s.verifier.stop()
 	s.coreStarted = false
 	return nil
 }
 
+// Close releases the signer, e.g. cancels the pending calls of a remote signer
 func (s *Backend) Close() error {
+	if closer, ok := s.signer.(io.Closer); ok {
+		return closer.Close()
+	}
 	return nil
 }
 
@@ -245,7 +310,7 @@ func (s *Backend) Close() error {
 // caller may optionally pass in a batch of parents (ascending order) to avoid
 // looking those up from the database. This is useful for concurrently verifying
 // a batch of new headers.
-func (s *Backend) verifyHeader(chain consensus.ChainHeaderReader, header *types.Header, parents []*types.Header, seal bool) error {
+func (s *Backend) verifyHeader(chain consensus.ChainHeaderReader, header *types.Header, parents []*types.Header, seal bool, abort <-chan struct{}) error {
 	if header.Number == nil {
 		return hs.ErrUnknownBlock
 	}
@@ -282,18 +347,44 @@ func (s *Backend) verifyHeader(chain consensus.ChainHeaderReader, header *types.
 		return hs.ErrInvalidTimestamp
 	}
 
-	if header.Time < parent.Time+s.config.BlockPeriod {
-		s.logger.Debug("TIME DIFF", "header", header.Time, "parent + BP", parent.Time+s.config.BlockPeriod)
+	// whole seconds are compared with the clock above, leaving replicas whose clock is
+	// slightly behind the leader's a margin, and milliseconds with the parent
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil || extra.Millis >= 1000 {
+		return hs.ErrInvalidTimestamp
+	}
+	period := s.config.Period()
+	if header.TxHash == types.EmptyRootHash {
+		period = s.config.EmptyPeriod()
+	}
+	if headerTime, minTime := types.HotstuffHeaderTime(header), types.HotstuffHeaderTime(parent).Add(period); headerTime.Before(minTime) {
+		s.logger.Debug("TIME DIFF", "header", headerTime, "parent + BP", minTime)
 		return hs.ErrInvalidTimestamp
 	}
 
-	// [TODO] Verify validators in extraData. Validators in snapshot and extraData should be the same.
+	// the validators recorded in extraData are the set governing the header, which
+	// the header is verified with. Batches of headers are verified within their epochs
+	// even if the local head already follows a later set.
+	snap, err := s.validators(chain, parent, abort)
+	if err != nil {
+		return err
+	}
+	if !sameMembers(snap.AddressList(), extra.Validators) {
+		return hs.ErrInvalidValidators
+	}
+	// the beneficiaries of the block reward must resolve from the headers
+	if _, _, err := s.blockReward(chain.Config(), header, parent); err != nil {
+		return err
+	}
 
 	// Resolve auth key and check against signers
 	if _, err := s.signer.RecoverSigner(header); err != nil {
 		return err
 	}
+	// the keys voted in before the header may sign its QC
+	if err := s.verifyKeyVote(chain, header, extra, parents, abort); err != nil {
+		return err
+	}
 
-	snap := s.snap().Copy()
 	return s.signer.VerifyHeader(header, snap, seal)
 }
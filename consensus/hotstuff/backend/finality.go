@@ -0,0 +1,45 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// finalityLookback is the maximum number of headers searched back from the chain
+	// head for a valid commit QC
+	finalityLookback = 64
+
+	// inmemoryFinalized is the number of recent headers whose commit QC is known valid
+	inmemoryFinalized = 128
+)
+
+// FinalizedHeader implements consensus.FinalityReader.FinalizedHeader. A block is
+// final once its header carries a valid commit QC, which is the case for every
+// committed block, so this is usually the chain head. Headers are verified with the
+// validator set of their epoch, and only once, the result is cached by hash.
+func (s *Backend) FinalizedHeader(chain consensus.ChainHeaderReader) *types.Header {
+	header := chain.CurrentHeader()
+	for i := 0; header != nil && i < finalityLookback; i++ {
+		number := header.Number.Uint64()
+		if number == 0 {
+			return header
+		}
+		hash := header.Hash()
+		if _, ok := s.finalizedHeaders.Get(hash); ok {
+			return header
+		}
+		parent := chain.GetHeader(header.ParentHash, number-1)
+		if parent == nil {
+			return nil
+		}
+		if snap, err := s.validators(chain, parent, nil); err == nil {
+			if err := s.signer.VerifyHeader(header, snap, true); err == nil {
+				s.finalizedHeaders.Add(hash, true)
+				return header
+			}
+		}
+		header = parent
+	}
+	return nil
+}
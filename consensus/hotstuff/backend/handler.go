@@ -1,11 +1,6 @@
 package backend
 
 import (
-	"bytes"
-	"io/ioutil"
-	"math/big"
-	"reflect"
-
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
@@ -15,11 +10,6 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 )
 
-const (
-	NewBlockMsg = 0x07
-	hotstuffMsg = 0x11
-)
-
 func (s *Backend) decode(msg p2p.Msg) ([]byte, common.Hash, error) {
 	var data []byte
 	if err := msg.Decode(&data); err != nil {
@@ -38,65 +28,118 @@ func (sb *Backend) Protocol() consensus.Protocol {
 func (s *Backend) HandleMsg(addr common.Address, msg p2p.Msg) (bool, error) {
 	s.coreMu.Lock()
 	defer s.coreMu.Unlock()
-	if msg.Code == hotstuffMsg {
+	if isConsensusMsg(msg.Code) {
 		if !s.coreStarted {
 			return true, ErrStoppedEngine
 		}
 
-		data, hash, err := s.decode(msg)
+		data, _, err := s.decode(msg)
 		if err != nil {
 			return true, hs.ErrDecodeFailed
 		}
-		// Mark peer's message
-		ms, ok := s.recentMessages.Get(addr)
-		var m *lru.ARCCache
-		if ok {
-			m, _ = ms.(*lru.ARCCache)
-		} else {
-			m, _ = lru.NewARC(inmemoryMessages)
-			s.recentMessages.Add(addr, m)
+		return true, s.handleConsensusPayload(addr, msg.Code, data)
+	}
+	if msg.Code == bundleMsg || msg.Code == compactPrepareMsg {
+		if !s.coreStarted {
+			return true, ErrStoppedEngine
 		}
-		m.Add(hash, true)
 
-		// Mark self known message
+		data, hash, err := s.decode(msg)
+		if err != nil {
+			return true, hs.ErrDecodeFailed
+		}
 		if _, ok := s.knownMessages.Get(hash); ok {
 			return true, nil
 		}
 		s.knownMessages.Add(hash, true)
 
-		go s.eventMux.Post(hs.MessageEvent{
-			Src:     addr,
-			Payload: data,
-		})
-		return true, nil
+		if msg.Code == compactPrepareMsg {
+			return true, s.handleCompactPrepare(addr, data)
+		}
+		return true, s.handleBundle(data)
 	}
-	if msg.Code == NewBlockMsg && s.core.IsProposer() { // eth.NewBlockMsg: import cycle
-		// this case is to safeguard the race of similar block which gets propagated from other node while this node is proposing
-		// as p2p.Msg can only be decoded once (get EOF for any subsequence read), we need to make sure the payload is restored after we decode it
-		s.logger.Debug("proposer received NewBlockMsg", "size", msg.Size, "payload.type", reflect.TypeOf(msg.Payload), "sender", addr)
-		if reader, ok := msg.Payload.(*bytes.Reader); ok {
-			payload, err := ioutil.ReadAll(reader)
-			if err != nil {
-				return true, err
-			}
-			reader.Reset(payload)       // ready to be decoded
-			defer reader.Reset(payload) // restore so main eth/handler can decode
-			var request struct {        // this has to be same as eth/protocol.go#newBlockData as we are reading NewBlockMsg
-				Block *types.Block
-				TD    *big.Int
-			}
-			if err := msg.Decode(&request); err != nil {
-				s.logger.Debug("Proposer was unable to decode the NewBlockMsg", "error", err)
-				return false, nil
+	if msg.Code == getProposalTxsMsg || msg.Code == proposalTxsMsg {
+		if !s.coreStarted {
+			return true, ErrStoppedEngine
+		}
+
+		data, _, err := s.decode(msg)
+		if err != nil {
+			return true, hs.ErrDecodeFailed
+		}
+		if msg.Code == getProposalTxsMsg {
+			return true, s.handleGetProposalTxs(addr, data)
+		}
+		return true, s.handleProposalTxs(addr, data)
+	}
+	// observers do not run the core, nor do validators need it to take subscriptions
+	if msg.Code == subscribeDecidedMsg {
+		if _, _, err := s.decode(msg); err != nil {
+			return true, hs.ErrDecodeFailed
+		}
+		return true, s.handleSubscribeDecided(addr)
+	}
+	if msg.Code == decidedBlockMsg {
+		data, hash, err := s.decode(msg)
+		if err != nil {
+			return true, hs.ErrDecodeFailed
+		}
+		if _, ok := s.knownMessages.Get(hash); ok {
+			return true, nil
+		}
+		return true, s.handleDecidedBlock(addr, hash, data)
+	}
+	return false, nil
+}
+
+// handleConsensusPayload delivers a consensus message received from addr to the core
+func (s *Backend) handleConsensusPayload(addr common.Address, code uint64, data []byte) error {
+	hash := hs.RLPHash(data)
+
+	// Mark peer's message
+	ms, ok := s.recentMessages.Get(addr)
+	var m *lru.ARCCache
+	if ok {
+		m, _ = ms.(*lru.ARCCache)
+	} else {
+		m, _ = lru.NewARC(inmemoryMessages)
+		s.recentMessages.Add(addr, m)
+	}
+	m.Add(hash, true)
+
+	// Mark self known message
+	if _, ok := s.knownMessages.Get(hash); ok {
+		return nil
+	}
+	s.knownMessages.Add(hash, true)
+
+	// relayed messages are delivered on behalf of their signer, whose signature is
+	// not checked again by the verifier
+	ev := hs.MessageEvent{Src: addr, Payload: data}
+	if s.config.Topology == hs.Tree {
+		if msg, err := s.decodeTreeMsg(data); err == nil {
+			if uint64(msg.Code) != code {
+				return errMsgCodeMismatch
 			}
-			newRequestedBlock := request.Block
-			if newRequestedBlock.Header().MixDigest == types.HotstuffDigest && s.core.IsCurrentProposal(newRequestedBlock.Hash()) {
-				s.logger.Debug("Proposer already proposed this block", "hash", newRequestedBlock.Hash(), "sender", addr)
-				return true, nil
+			ev.Src, ev.Msg = msg.Address, msg
+			if s.treeRouted(msg) && !isVote(msg.Code) {
+				s.relayTree(s.validatorSet(), msg.Address, code, data)
 			}
 		}
 	}
-	return false, nil
+
+	s.verifier.submit(ev)
+	return nil
+}
+
+// SetTxPool implements consensus.TxPoolHandler.SetTxPool
+func (s *Backend) SetTxPool(txPool consensus.TxPool) {
+	s.txPool = txPool
+}
+
+// SetChainInserter implements consensus.ChainInserterHandler.SetChainInserter
+func (s *Backend) SetChainInserter(inserter consensus.ChainInserter) {
+	s.inserter = inserter
 }
 
 // SetBroadcaster implements consensus.Handler.SetBroadcaster
@@ -121,3 +164,8 @@ func (s *Backend) NewChainHead(header *types.Header) error {
 func (s *Backend) SubscribeBlock(ch chan<- consensus.ExecutedBlock) event.Subscription {
 	return s.executeFeed.Subscribe(ch)
 }
+
+// SubscribeSpeculative implements consensus.Speculator
+func (s *Backend) SubscribeSpeculative(ch chan<- consensus.SpeculativeParent) event.Subscription {
+	return s.speculateFeed.Subscribe(ch)
+}
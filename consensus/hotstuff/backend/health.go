@@ -0,0 +1,116 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Health is the consensus liveness of the node, as reported to orchestration probes
+type Health struct {
+	Healthy         bool     `json:"healthy"`
+	Reasons         []string `json:"reasons,omitempty"` // Why the node is unhealthy
+	Height          uint64   `json:"height"`
+	Round           uint64   `json:"round"`
+	SinceLastCommit int64    `json:"sinceLastCommit"` // Milliseconds since the timestamp of the chain head
+	Validators      int      `json:"validators"`      // Validators connected, counting the node itself
+	VotingPower     int      `json:"votingPower"`     // Voting power of the validators connected
+	Quorum          int      `json:"quorum"`          // Voting power of a quorum
+}
+
+// Health checks that the engine is making progress: a block was committed within
+// the configured number of block periods, or of empty block periods while there is
+// no transaction to include, the round of the current height stays below its limit,
+// and the validators connected hold the voting power of a quorum.
+func (s *Backend) Health() *Health {
+	s.coreMu.RLock()
+	started, chain := s.coreStarted, s.chain
+	s.coreMu.RUnlock()
+
+	valSet := s.validatorSet()
+	health := &Health{Quorum: valSet.Q()}
+	if !started || chain == nil {
+		health.Reasons = append(health.Reasons, "engine not started")
+		return health
+	}
+	health.Height, health.Round = s.core.CurrentSequence()
+
+	head := chain.CurrentHeader()
+	since := time.Since(types.HotstuffHeaderTime(head))
+	health.SinceLastCommit = since.Milliseconds()
+	if periods := s.config.HealthCommitPeriods; periods != 0 {
+		if limit := time.Duration(periods) * s.commitPeriod(); since > limit {
+			health.Reasons = append(health.Reasons, fmt.Sprintf("no block committed for %v since block %d, limit %v", since.Round(time.Millisecond), head.Number, limit))
+		}
+	}
+	if limit := s.config.HealthMaxRound; limit != 0 && health.Round >= limit {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("round %d at height %d, limit %d", health.Round, health.Height, limit))
+	}
+
+	health.Validators, health.VotingPower = s.connectedValidators(valSet)
+	if health.VotingPower < health.Quorum {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("voting power %d connected, quorum %d", health.VotingPower, health.Quorum))
+	}
+	if required := int(s.config.HealthPeers); health.Validators < required {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("%d validators connected, %d required", health.Validators, required))
+	}
+	health.Healthy = len(health.Reasons) == 0
+	return health
+}
+
+// commitPeriod returns the time expected between two commits: the block period while
+// the local pool holds executable transactions, and the empty block period otherwise
+// or if the node has no pool to tell
+func (s *Backend) commitPeriod() time.Duration {
+	if s.txPool != nil {
+		if pending, err := s.txPool.Pending(); err == nil && len(pending) > 0 {
+			return s.config.Period()
+		}
+	}
+	return s.config.EmptyPeriod()
+}
+
+// connectedValidators returns the number and the voting power of the validators
+// connected as peers, and of the node itself if it is a validator
+func (s *Backend) connectedValidators(valSet hs.ValidatorSet) (int, int) {
+	var (
+		targets = make(map[common.Address]bool)
+		count   = 0
+		power   = 0
+	)
+	for _, val := range valSet.List() {
+		if val.Address() == s.Address() {
+			count, power = 1, val.Weight()
+			continue
+		}
+		targets[val.Address()] = true
+	}
+	if s.broadcaster == nil {
+		return count, power
+	}
+	for addr := range s.broadcaster.FindPeers(targets) {
+		if _, val := valSet.GetByAddress(addr); val != nil {
+			count++
+			power += val.Weight()
+		}
+	}
+	return count, power
+}
+
+// HealthHandler serves the consensus liveness as JSON, with status 200 if the node
+// is healthy and 503 otherwise
+func (s *Backend) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := s.Health()
+		w.Header().Set("Content-Type", "application/json")
+		if !health.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(health)
+	})
+}
@@ -0,0 +1,322 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	dbKeyVotesPrefix = "hotstuff-keyvotes-"
+
+	// keyCheckpointInterval is the number of blocks after which the key votes are
+	// stored in the database
+	keyCheckpointInterval = 1024
+)
+
+var (
+	errInvalidKeyVote = errors.New("invalid BLS key vote")
+	errRegisteredKey  = errors.New("BLS public key already registered")
+)
+
+// keyVote is the pending vote of a validator for registering a BLS public key
+type keyVote struct {
+	Voter common.Address
+	Key   *snr.BLSPublicKey
+}
+
+// keySnapshot is the state of the BLS key votes after a block. A key is registered
+// once validators holding a quorum of the voting power governing a block voted for
+// it, and is never replaced afterwards.
+type keySnapshot struct {
+	Number uint64
+	Hash   common.Hash
+	Votes  []*keyVote          // Pending votes, one per voter and address
+	Keys   []*snr.BLSPublicKey // Keys registered by the votes, in registration order
+}
+
+func keySnapshotKey(hash common.Hash) []byte {
+	return append([]byte(dbKeyVotesPrefix), hash.Bytes()...)
+}
+
+// loadKeySnapshot loads the key votes after the block of the given hash from the
+// database
+func loadKeySnapshot(db ethdb.Database, hash common.Hash) (*keySnapshot, error) {
+	blob, err := db.Get(keySnapshotKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	snap := new(keySnapshot)
+	if err := rlp.DecodeBytes(blob, snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// store inserts the snapshot into the database
+func (snap *keySnapshot) store(db ethdb.Database) error {
+	blob, err := rlp.EncodeToBytes(snap)
+	if err != nil {
+		return err
+	}
+	return db.Put(keySnapshotKey(snap.Hash), blob)
+}
+
+// copy creates a copy of the snapshot, the votes and keys themselves are shared as
+// they are never changed
+func (snap *keySnapshot) copy() *keySnapshot {
+	return &keySnapshot{
+		Number: snap.Number,
+		Hash:   snap.Hash,
+		Votes:  append([]*keyVote{}, snap.Votes...),
+		Keys:   append([]*snr.BLSPublicKey{}, snap.Keys...),
+	}
+}
+
+// registered reports whether a key was registered for the address
+func (snap *keySnapshot) registered(addr common.Address) bool {
+	for _, key := range snap.Keys {
+		if key.Address == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// voted reports whether the voter has a pending vote for the key
+func (snap *keySnapshot) voted(voter common.Address, key *snr.BLSPublicKey) bool {
+	for _, vote := range snap.Votes {
+		if vote.Voter == voter && vote.Key.Address == key.Address && bytes.Equal(vote.Key.PublicKey, key.PublicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+// cast replaces the vote of the voter for the address of key, and registers the key
+// if the voters for it hold a quorum of the voting power of valSet. It reports
+// whether the key was registered.
+func (snap *keySnapshot) cast(voter common.Address, key *snr.BLSPublicKey, valSet hs.ValidatorSet) bool {
+	votes := snap.Votes[:0]
+	for _, vote := range snap.Votes {
+		if vote.Voter != voter || vote.Key.Address != key.Address {
+			votes = append(votes, vote)
+		}
+	}
+	votes = append(votes, &keyVote{Voter: voter, Key: key})
+
+	weight := 0
+	for _, vote := range votes {
+		if vote.Key.Address != key.Address || !bytes.Equal(vote.Key.PublicKey, key.PublicKey) {
+			continue
+		}
+		if _, val := valSet.GetByAddress(vote.Voter); val != nil {
+			weight += val.Weight()
+		}
+	}
+	if weight < valSet.Q() {
+		snap.Votes = votes
+		return false
+	}
+
+	// the pending votes for the address are dropped with the registration
+	snap.Votes = votes[:0]
+	for _, vote := range votes {
+		if vote.Key.Address != key.Address {
+			snap.Votes = append(snap.Votes, vote)
+		}
+	}
+	snap.Keys = append(snap.Keys, key)
+	return true
+}
+
+// keySnapshot returns the BLS key votes after the block of the given number and
+// hash. The caller may pass in a batch of parents (ascending order) which are not
+// in the chain yet.
+func (s *Backend) keySnapshot(chain consensus.ChainHeaderReader, number uint64, hash common.Hash, parents []*types.Header, abort <-chan struct{}) (*keySnapshot, error) {
+	var (
+		headers []*types.Header
+		snap    *keySnapshot
+	)
+	for snap == nil {
+		if cached, ok := s.keySnapshots.Get(hash); ok {
+			snap = cached.(*keySnapshot)
+			break
+		}
+		if number%keyCheckpointInterval == 0 {
+			if stored, err := loadKeySnapshot(s.db, hash); err == nil {
+				snap = stored
+				break
+			}
+		}
+		// keys registered in genesis are known to the signer, votes start after it
+		if number == 0 {
+			snap = &keySnapshot{Hash: hash}
+			break
+		}
+
+		// no snapshot for this header, gather it and move backward
+		var header *types.Header
+		if len(parents) > 0 {
+			header = parents[len(parents)-1]
+			if header.Hash() != hash || header.Number.Uint64() != number {
+				return nil, consensus.ErrUnknownAncestor
+			}
+			parents = parents[:len(parents)-1]
+		} else {
+			header = chain.GetHeader(hash, number)
+			if header == nil {
+				return nil, consensus.ErrUnknownAncestor
+			}
+		}
+		headers = append(headers, header)
+		number, hash = number-1, header.ParentHash
+	}
+
+	// apply the votes of the gathered headers, oldest first. The snapshot found may
+	// be the one of a parent in the batch.
+	snap = snap.copy()
+	parent := chain.GetHeader(snap.Hash, snap.Number)
+	if parent == nil && len(parents) > 0 {
+		parent = parents[len(parents)-1]
+	}
+	for i := len(headers) - 1; i >= 0; i-- {
+		header := headers[i]
+		if parent == nil || parent.Hash() != header.ParentHash {
+			return nil, consensus.ErrUnknownAncestor
+		}
+		if err := s.applyKeyVote(chain, snap, parent, header, abort); err != nil {
+			return nil, err
+		}
+		snap.Number, snap.Hash = header.Number.Uint64(), header.Hash()
+		parent = header
+
+		if snap.Number%keyCheckpointInterval == 0 {
+			if err := snap.store(s.db); err != nil {
+				return nil, err
+			}
+		}
+	}
+	s.keySnapshots.Add(snap.Hash, snap)
+	return snap, nil
+}
+
+// applyKeyVote applies the key vote of a header to the snapshot of its parent
+func (s *Backend) applyKeyVote(chain consensus.ChainHeaderReader, snap *keySnapshot, parent, header *types.Header, abort <-chan struct{}) error {
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil {
+		return err
+	}
+	if extra.KeyVote == nil {
+		return nil
+	}
+	voter, err := s.signer.RecoverSigner(header)
+	if err != nil {
+		return err
+	}
+	valSet, err := s.validators(chain, parent, abort)
+	if err != nil {
+		return err
+	}
+	key := &snr.BLSPublicKey{
+		Address:   extra.KeyVote.Address,
+		PublicKey: extra.KeyVote.PublicKey,
+		Proof:     extra.KeyVote.Proof,
+	}
+	if snap.cast(voter, key, valSet) {
+		s.logger.Info("Registered voted BLS public key", "number", header.Number, "validator", key.Address)
+	}
+	return nil
+}
+
+// verifyKeyVote checks the key vote of a header against the key votes after its
+// parent, and registers the keys voted in by then with the multi-signature scheme,
+// whose QC of the header they may sign
+func (s *Backend) verifyKeyVote(chain consensus.ChainHeaderReader, header *types.Header, extra *types.HotstuffExtra, parents []*types.Header, abort <-chan struct{}) error {
+	multi := s.multiSigner()
+	if multi == nil {
+		if extra.KeyVote != nil {
+			return errInvalidKeyVote
+		}
+		return nil
+	}
+	number := header.Number.Uint64()
+	snap, err := s.keySnapshot(chain, number-1, header.ParentHash, parents, abort)
+	if err != nil {
+		return err
+	}
+	if err := s.registerVotedKeys(multi, snap); err != nil {
+		return err
+	}
+
+	if extra.KeyVote == nil {
+		return nil
+	}
+	if snap.registered(extra.KeyVote.Address) {
+		return errRegisteredKey
+	}
+	return multi.VerifyPublicKey(&snr.BLSPublicKey{
+		Address:   extra.KeyVote.Address,
+		PublicKey: extra.KeyVote.PublicKey,
+		Proof:     extra.KeyVote.Proof,
+	})
+}
+
+// registerVotedKeys registers the keys of a snapshot which the multi-signature
+// scheme does not know yet
+func (s *Backend) registerVotedKeys(multi *snr.MultiSigner, snap *keySnapshot) error {
+	for _, key := range snap.Keys {
+		if multi.HasPublicKey(key.Address) {
+			continue
+		}
+		if err := multi.AddPublicKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prepareKeyVote casts the vote of the node for one of the keys it proposes, which
+// is neither registered after parent nor voted for by the node yet
+func (s *Backend) prepareKeyVote(chain consensus.ChainHeaderReader, header, parent *types.Header) error {
+	multi := s.multiSigner()
+	if multi == nil {
+		return nil
+	}
+	snap, err := s.keySnapshot(chain, parent.Number.Uint64(), parent.Hash(), nil, nil)
+	if err != nil {
+		return err
+	}
+	if err := s.registerVotedKeys(multi, snap); err != nil {
+		return err
+	}
+
+	s.sigMu.RLock()
+	defer s.sigMu.RUnlock()
+
+	addrs := make([]common.Address, 0, len(s.keyProposals))
+	for addr := range s.keyProposals {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i][:], addrs[j][:]) < 0 })
+	for _, addr := range addrs {
+		key := s.keyProposals[addr]
+		if snap.registered(addr) || snap.voted(s.Address(), key) {
+			continue
+		}
+		return header.SetHotstuffKeyVote(&types.HotstuffKeyVote{
+			Address:   key.Address,
+			PublicKey: key.PublicKey,
+			Proof:     key.Proof,
+		})
+	}
+	return nil
+}
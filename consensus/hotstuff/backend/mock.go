@@ -11,17 +11,20 @@ func (s *Backend) MockSeal(chain consensus.ChainHeaderReader, block *types.Block
 	header := block.Header()
 	number := header.Number.Uint64()
 
-	// bail out if we're unauthorized to sign a block
-	snap := s.snap()
-	if _, v := snap.GetByAddress(s.Address()); v == nil {
-		return hs.ErrUnauthorized
-	}
-
 	parent := chain.GetHeader(header.ParentHash, number-1)
 	if parent == nil {
 		return consensus.ErrUnknownAncestor
 	}
 
+	// bail out if we're unauthorized to sign a block
+	snap, err := s.validators(chain, parent, nil)
+	if err != nil {
+		return err
+	}
+	if _, v := snap.GetByAddress(s.Address()); v == nil {
+		return hs.ErrUnauthorized
+	}
+
 	// sign the HotstuffExtra.Seal portion with ECDSA
 	if err = s.signer.SignerSeal(header); err != nil {
 		return err
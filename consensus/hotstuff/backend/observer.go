@@ -0,0 +1,110 @@
+package backend
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// inmemoryObservers is the number of observer peers a validator forwards decided
+	// blocks to
+	inmemoryObservers = 128
+)
+
+// SubscribeDecided asks the validator at the other end of w to forward the blocks it
+// decides, sealed with their commit QC. Observers subscribe to every validator peer
+// right after the hotstuff/1 handshake.
+func (s *Backend) SubscribeDecided(w p2p.MsgWriter) error {
+	return p2p.Send(w, subscribeDecidedMsg, []byte{})
+}
+
+// handleSubscribeDecided records the peer at addr as an observer of decided blocks
+func (s *Backend) handleSubscribeDecided(addr common.Address) error {
+	if !s.config.DecideObservers {
+		return nil
+	}
+	if _, v := s.snap().GetByAddress(addr); v != nil {
+		return nil
+	}
+	s.logger.Debug("Observer subscribed to decided blocks", "observer", addr)
+	s.observers.Add(addr, true)
+	return nil
+}
+
+// forwardDecided sends a decided block, whose header carries the commit QC, to the
+// subscribed observers. Every validator forwards the block, so that observers get it
+// from the fastest one, and from the others if a validator is down.
+func (s *Backend) forwardDecided(block *types.Block) {
+	if !s.config.DecideObservers || s.observers.Len() == 0 {
+		return
+	}
+	payload, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		s.logger.Debug("Failed to encode decided block", "hash", block.Hash(), "err", err)
+		return
+	}
+	targets := make(map[common.Address]bool)
+	for _, addr := range s.observers.Keys() {
+		targets[addr.(common.Address)] = true
+	}
+	s.gossipTo(targets, decidedBlockMsg, payload)
+}
+
+// handleDecidedBlock verifies the proposer seal and the commit QC of a block forwarded
+// by a validator against the validator set of its epoch, and inserts the block into
+// the chain right away. Blocks which cannot be verified yet, e.g. because the parent is
+// missing, are left to the regular sync and may still be taken from another
+// validator; only a forged block fails the peer.
+func (s *Backend) handleDecidedBlock(addr common.Address, hash common.Hash, data []byte) error {
+	if !s.config.Observer {
+		return nil
+	}
+	var block *types.Block
+	if err := rlp.DecodeBytes(data, &block); err != nil {
+		return hs.ErrDecodeFailed
+	}
+	header := block.Header()
+	extra, err := types.ExtractHotstuffExtra(header)
+	if err != nil {
+		return hs.ErrInvalidExtraDataFormat
+	}
+	qc, err := decodeCommitQC(extra)
+	if err != nil {
+		return err
+	}
+	if qc.Code != hs.MsgTypeCommitVote || qc.View.Height.Cmp(header.Number) != 0 {
+		return hs.ErrInvalidQC
+	}
+	inserter := s.inserter
+	if inserter == nil {
+		return nil
+	}
+	number := block.NumberU64()
+	if inserter.GetHeader(block.Hash(), number) != nil {
+		s.knownMessages.Add(hash, true)
+		return nil
+	}
+	parent := inserter.GetHeader(block.ParentHash(), number-1)
+	if parent == nil {
+		s.logger.Debug("Decided block parent unknown, leaving it to sync", "src", addr, "hash", block.Hash(), "number", number)
+		return nil
+	}
+	valSet, err := s.validators(inserter, parent, nil)
+	if err != nil {
+		s.logger.Debug("Decided block validators unknown, leaving it to sync", "src", addr, "hash", block.Hash(), "number", number, "err", err)
+		return nil
+	}
+	if err := s.signer.VerifyHeader(header, valSet, true); err != nil {
+		return err
+	}
+	s.logger.Debug("Insert decided block", "src", addr, "hash", block.Hash(), "number", number)
+	if _, err := inserter.InsertChain(types.Blocks{block}); err != nil {
+		s.logger.Debug("Failed to insert decided block", "hash", block.Hash(), "number", number, "err", err)
+		return nil
+	}
+	s.knownMessages.Add(hash, true)
+	return nil
+}
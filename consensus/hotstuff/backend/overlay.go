@@ -0,0 +1,463 @@
+package backend
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// treeFlushDivisor bounds how long an internal node waits for the votes of
+	// its subtree, as a fraction of the request timeout
+	treeFlushDivisor = 8
+
+	// treeBundleHeights is the number of heights for which vote bundles are kept
+	treeBundleHeights = 2
+)
+
+var errAggregateScheme = errors.New("aggregated vote outside the multi-signature scheme")
+
+// overlayTree is a Kauri-style overlay over the validator set, rooted at the
+// leader of a view. The remaining validators follow the root in validator set
+// order and are laid out breadth-first, so that node i has the children
+// fanout*i+1 ... fanout*i+fanout.
+type overlayTree struct {
+	nodes  []common.Address
+	index  map[common.Address]int
+	fanout int
+}
+
+// newOverlayTree builds the tree of valSet rooted at root, or returns nil if root
+// is not a validator
+func newOverlayTree(valSet hs.ValidatorSet, root common.Address, fanout int) *overlayTree {
+	start, val := valSet.GetByAddress(root)
+	if val == nil {
+		return nil
+	}
+	vals := valSet.List()
+	if fanout <= 0 {
+		fanout = int(math.Ceil(math.Sqrt(float64(len(vals)))))
+	}
+	if fanout < 2 {
+		fanout = 2
+	}
+
+	t := &overlayTree{
+		nodes:  make([]common.Address, len(vals)),
+		index:  make(map[common.Address]int, len(vals)),
+		fanout: fanout,
+	}
+	for i := range vals {
+		addr := vals[(start+i)%len(vals)].Address()
+		t.nodes[i] = addr
+		t.index[addr] = i
+	}
+	return t
+}
+
+// parent returns the parent of addr, false if addr is the root or not in the tree
+func (t *overlayTree) parent(addr common.Address) (common.Address, bool) {
+	i, ok := t.index[addr]
+	if !ok || i == 0 {
+		return common.Address{}, false
+	}
+	return t.nodes[(i-1)/t.fanout], true
+}
+
+// children returns the children of addr
+func (t *overlayTree) children(addr common.Address) []common.Address {
+	i, ok := t.index[addr]
+	if !ok {
+		return nil
+	}
+	var children []common.Address
+	for c := t.fanout*i + 1; c <= t.fanout*i+t.fanout && c < len(t.nodes); c++ {
+		children = append(children, t.nodes[c])
+	}
+	return children
+}
+
+// subtreeSize returns the number of nodes in the subtree of addr, addr included
+func (t *overlayTree) subtreeSize(addr common.Address) int {
+	i, ok := t.index[addr]
+	if !ok {
+		return 0
+	}
+	size := 0
+	for lo, hi := i, i; lo < len(t.nodes); lo, hi = t.fanout*lo+1, t.fanout*hi+t.fanout {
+		if hi >= len(t.nodes) {
+			hi = len(t.nodes) - 1
+		}
+		size += hi - lo + 1
+	}
+	return size
+}
+
+// voteBundle carries the votes of a subtree up to the parent in a single message.
+// Under the multi-signature scheme the votes on the same block are aggregated into a
+// single vote, whose BLS signature share holds the signer bitmap and the aggregated
+// signature. Under the threshold scheme shares cannot be aggregated before the quorum
+// is reached, and the signed votes are carried as they are.
+type voteBundle struct {
+	Root       common.Address // Leader the votes are destined for
+	Payloads   [][]byte       // Signed vote messages
+	Aggregates []*hs.Vote     // Aggregated votes, under the multi-signature scheme
+}
+
+type bundleKey struct {
+	root   common.Address
+	height uint64
+	round  uint64
+	code   hs.MsgType
+}
+
+// pendingBundle collects the votes of the local subtree until every node of the
+// subtree has voted or the flush delay expires. Votes arriving after the flush are
+// relayed on their own.
+type pendingBundle struct {
+	parent   common.Address
+	expected int
+	count    int                      // Validators whose votes were added
+	payloads [][]byte                 // Signed votes, under the threshold scheme
+	votes    map[common.Hash]*hs.Vote // Unsigned votes by hash, under the multi-signature scheme
+	shares   map[common.Hash][][]byte // BLS signature shares of the votes by hash
+	flushed  bool
+}
+
+// treeRouted reports whether a message is routed over the overlay tree. Only the
+// first round of a height uses the tree, so that the view change following a
+// timeout falls back to the star topology.
+func (s *Backend) treeRouted(msg *hs.Message) bool {
+	if s.config.Topology != hs.Tree || msg.View == nil || msg.View.RoundU64() != 0 {
+		return false
+	}
+	switch msg.Code {
+	case hs.MsgTypePrepare, hs.MsgTypePreCommit, hs.MsgTypeCommit, hs.MsgTypeDecide,
+		hs.MsgTypePrepareVote, hs.MsgTypePreCommitVote, hs.MsgTypeCommitVote:
+		return true
+	}
+	return false
+}
+
+func isVote(code hs.MsgType) bool {
+	return code == hs.MsgTypePrepareVote || code == hs.MsgTypePreCommitVote || code == hs.MsgTypeCommitVote
+}
+
+// decodeTreeMsg decodes a message and recovers the validator which signed it, as
+// relayed messages do not come from their sender. The message is returned with the
+// address of its signer, ready for the core.
+func (s *Backend) decodeTreeMsg(payload []byte) (*hs.Message, error) {
+	msg := new(hs.Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil {
+		return nil, err
+	}
+	if msg.View == nil || msg.Msg == nil {
+		return nil, hs.ErrInvalidMessage
+	}
+	hash, err := msg.Hash()
+	if err != nil {
+		return nil, err
+	}
+	origin, err := s.signer.CheckSignature(s.validatorSet(), hash, msg.Signature)
+	if err != nil {
+		return nil, err
+	}
+	msg.Address = origin
+	return msg, nil
+}
+
+func (s *Backend) treeFanout() int {
+	return int(s.config.TreeFanout)
+}
+
+// relayTree sends a leader message to the children of this node in the tree rooted
+// at the leader. Unreachable children are bypassed by sending to their own children.
+func (s *Backend) relayTree(valSet hs.ValidatorSet, root common.Address, code uint64, payload []byte) {
+	tree := newOverlayTree(valSet, root, s.treeFanout())
+	if tree == nil || s.broadcaster == nil {
+		return
+	}
+
+	targets := make(map[common.Address]bool)
+	queue := tree.children(s.Address())
+	for len(queue) > 0 {
+		child := queue[0]
+		queue = queue[1:]
+		if s.broadcaster.FindPeer(child) != nil {
+			targets[child] = true
+		} else {
+			queue = append(queue, tree.children(child)...)
+		}
+	}
+	s.gossipTo(targets, code, payload)
+}
+
+// treeVote is a vote travelling up the overlay tree: a signed vote message, or a
+// vote aggregated from several validators under the multi-signature scheme
+type treeVote struct {
+	view    *hs.View
+	code    hs.MsgType
+	payload []byte   // Signed vote message, nil for an aggregated vote
+	vote    *hs.Vote // Vote with its BLS signature share, nil under the threshold scheme
+	signers int      // Number of validators which signed the vote
+}
+
+// newTreeVote returns the tree vote of a signed vote message. Under the multi-signature
+// scheme the BLS signature share of the vote is verified, unless it is the vote of
+// this node, as it is aggregated with others.
+func (s *Backend) newTreeVote(msg *hs.Message, payload []byte) (*treeVote, error) {
+	tv := &treeVote{view: msg.View, code: msg.Code, payload: payload, signers: 1}
+	multi := s.multiSigner()
+	if multi == nil {
+		return tv, nil
+	}
+	if err := msg.Decode(&tv.vote); err != nil {
+		return nil, err
+	}
+	if tv.vote == nil || tv.vote.Code != msg.Code {
+		return nil, hs.ErrInvalidMessage
+	}
+	if msg.Address != s.Address() {
+		data, err := hs.Encode(tv.vote.Unsigned())
+		if err != nil {
+			return nil, err
+		}
+		signers, err := multi.ShareSigners(data, tv.vote.BLSSignature)
+		if err != nil {
+			return nil, err
+		}
+		if len(signers) != 1 || signers[0] != msg.Address {
+			return nil, hs.ErrInvalidSigner
+		}
+	}
+	return tv, nil
+}
+
+// aggregateTreeVote returns the tree vote of an aggregated vote after verifying its
+// BLS signature share, along with its signers
+func (s *Backend) aggregateTreeVote(vote *hs.Vote) (*treeVote, []common.Address, error) {
+	multi := s.multiSigner()
+	if multi == nil {
+		return nil, nil, errAggregateScheme
+	}
+	if vote == nil || vote.View == nil || !isVote(vote.Code) {
+		return nil, nil, hs.ErrInvalidMessage
+	}
+	data, err := hs.Encode(vote.Unsigned())
+	if err != nil {
+		return nil, nil, err
+	}
+	signers, err := multi.ShareSigners(data, vote.BLSSignature)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &treeVote{view: vote.View, code: vote.Code, vote: vote, signers: len(signers)}, signers, nil
+}
+
+// addTreeVote adds a vote to the bundle of the local subtree, returning false if
+// this node has no parent in the tree rooted at root
+func (s *Backend) addTreeVote(root common.Address, tv *treeVote) bool {
+	tree := newOverlayTree(s.validatorSet(), root, s.treeFanout())
+	if tree == nil {
+		return false
+	}
+	parent, ok := tree.parent(s.Address())
+	if !ok {
+		return false
+	}
+
+	key := bundleKey{root: root, height: tv.view.HeightU64(), round: tv.view.RoundU64(), code: tv.code}
+
+	s.bundlesMu.Lock()
+	defer s.bundlesMu.Unlock()
+
+	bundle, ok := s.bundles[key]
+	if !ok {
+		for k := range s.bundles {
+			if k.height+treeBundleHeights < key.height {
+				delete(s.bundles, k)
+			}
+		}
+		bundle = &pendingBundle{
+			parent:   parent,
+			expected: tree.subtreeSize(s.Address()),
+			votes:    make(map[common.Hash]*hs.Vote),
+			shares:   make(map[common.Hash][][]byte),
+		}
+		s.bundles[key] = bundle
+		if bundle.expected > 1 {
+			delay := time.Duration(s.config.RequestTimeout/treeFlushDivisor) * time.Millisecond
+			time.AfterFunc(delay, func() { s.flushBundle(key) })
+		}
+	}
+	if bundle.flushed {
+		if tv.vote != nil {
+			s.sendBundle(root, parent, &voteBundle{Aggregates: []*hs.Vote{tv.vote}})
+		} else {
+			s.sendBundle(root, parent, &voteBundle{Payloads: [][]byte{tv.payload}})
+		}
+		return true
+	}
+	if tv.vote != nil {
+		hash := hs.RLPHash(tv.vote.Unsigned())
+		if _, ok := bundle.votes[hash]; !ok {
+			bundle.votes[hash] = tv.vote.Unsigned()
+		}
+		bundle.shares[hash] = append(bundle.shares[hash], tv.vote.BLSSignature)
+	} else {
+		bundle.payloads = append(bundle.payloads, tv.payload)
+	}
+	bundle.count += tv.signers
+	if bundle.count >= bundle.expected {
+		s.flushLocked(key, bundle)
+	}
+	return true
+}
+
+func (s *Backend) flushBundle(key bundleKey) {
+	s.bundlesMu.Lock()
+	defer s.bundlesMu.Unlock()
+
+	if bundle, ok := s.bundles[key]; ok && !bundle.flushed {
+		s.flushLocked(key, bundle)
+	}
+}
+
+// flushLocked sends the votes of a bundle to the parent, aggregating the shares of
+// every vote into one under the multi-signature scheme
+func (s *Backend) flushLocked(key bundleKey, bundle *pendingBundle) {
+	bundle.flushed = true
+
+	out := &voteBundle{Payloads: bundle.payloads}
+	if multi := s.multiSigner(); multi != nil {
+		for hash, vote := range bundle.votes {
+			data, err := hs.Encode(vote)
+			if err != nil {
+				s.logger.Error("Failed to encode vote", "err", err)
+				continue
+			}
+			share, err := multi.AggregateShares(data, bundle.shares[hash])
+			if err != nil {
+				s.logger.Warn("Failed to aggregate votes", "msgCode", key.code, "votes", len(bundle.shares[hash]), "err", err)
+				continue
+			}
+			agg := vote.Unsigned()
+			agg.BLSSignature = share
+			out.Aggregates = append(out.Aggregates, agg)
+		}
+	}
+	if len(out.Payloads) > 0 || len(out.Aggregates) > 0 {
+		s.sendBundle(key.root, bundle.parent, out)
+	}
+	bundle.payloads, bundle.votes, bundle.shares = nil, nil, nil
+}
+
+// sendBundle sends votes to the parent, falling back to the root if the parent is
+// unreachable
+func (s *Backend) sendBundle(root, parent common.Address, bundle *voteBundle) {
+	if s.broadcaster == nil {
+		return
+	}
+	bundle.Root = root
+	data, err := rlp.EncodeToBytes(bundle)
+	if err != nil {
+		s.logger.Error("Failed to encode vote bundle", "err", err)
+		return
+	}
+	p := s.broadcaster.FindPeer(parent)
+	if p == nil {
+		s.logger.Debug("Parent unreachable, sending votes to leader", "parent", parent, "leader", root)
+		if p = s.broadcaster.FindPeer(root); p == nil {
+			s.logger.Warn("Failed to send vote bundle", "leader", root, "err", "leader unreachable")
+			return
+		}
+	}
+	go func() {
+		if err := p.SendConsensus(bundleMsg, data); err != nil {
+			s.logger.Error("send vote bundle failed", "err", err)
+		}
+	}()
+}
+
+// handleBundle unpacks a vote bundle, delivering the votes to the core if this node
+// is the leader or adding them to the local bundle otherwise. The signatures of the
+// votes are checked once here, and the core is handed the decoded votes.
+func (s *Backend) handleBundle(data []byte) error {
+	var bundle voteBundle
+	if err := rlp.DecodeBytes(data, &bundle); err != nil {
+		return hs.ErrDecodeFailed
+	}
+	for _, payload := range bundle.Payloads {
+		hash := hs.RLPHash(payload)
+		if _, ok := s.knownMessages.Get(hash); ok {
+			continue
+		}
+		s.knownMessages.Add(hash, true)
+
+		msg, err := s.decodeTreeMsg(payload)
+		if err != nil || !isVote(msg.Code) {
+			s.logger.Debug("Dropping invalid bundled vote", "err", err)
+			continue
+		}
+		if bundle.Root != s.Address() {
+			tv, err := s.newTreeVote(msg, payload)
+			if err != nil {
+				s.logger.Debug("Dropping invalid bundled vote", "src", msg.Address, "err", err)
+				continue
+			}
+			if s.addTreeVote(bundle.Root, tv) {
+				continue
+			}
+		}
+		go s.eventMux.Post(hs.MessageEvent{
+			Src:     msg.Address,
+			Payload: payload,
+			Msg:     msg,
+		})
+	}
+	for _, vote := range bundle.Aggregates {
+		tv, signers, err := s.aggregateTreeVote(vote)
+		if err != nil {
+			s.logger.Debug("Dropping invalid aggregated vote", "err", err)
+			continue
+		}
+		if bundle.Root != s.Address() && s.addTreeVote(bundle.Root, tv) {
+			continue
+		}
+		s.deliverAggregate(vote, signers)
+	}
+	return nil
+}
+
+// deliverAggregate hands an aggregated vote to the core as the votes of its signers,
+// which all carry the aggregated share: the core counts the voting power of every
+// signer, and the share is aggregated once into the QC.
+func (s *Backend) deliverAggregate(vote *hs.Vote, signers []common.Address) {
+	payload, err := hs.Encode(vote)
+	if err != nil {
+		s.logger.Error("Failed to encode aggregated vote", "err", err)
+		return
+	}
+	for _, signer := range signers {
+		msg := &hs.Message{
+			Address: signer,
+			Code:    vote.Code,
+			View:    vote.View,
+			Msg:     payload,
+		}
+		data, err := msg.Payload()
+		if err != nil {
+			s.logger.Error("Failed to encode aggregated vote", "err", err)
+			return
+		}
+		go s.eventMux.Post(hs.MessageEvent{
+			Src:     signer,
+			Payload: data,
+			Msg:     msg,
+		})
+	}
+}
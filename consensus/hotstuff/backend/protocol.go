@@ -0,0 +1,158 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// hotstuff/1 message codes. Consensus messages use the value of their hs.MsgType,
+// i.e. 0x01 (NewView) to 0x08 (Decide), and 0x0f (Suspect).
+const (
+	statusMsg = 0x00 // Handshake proving the validator address of a peer
+	bundleMsg = 0x09 // Votes of a subtree under the Tree topology
+
+	compactPrepareMsg = 0x0a // Prepare carrying transaction hashes instead of the block
+	getProposalTxsMsg = 0x0b // Request for the transactions missing from a compact Prepare
+	proposalTxsMsg    = 0x0c // Transactions of a compact Prepare
+
+	subscribeDecidedMsg = 0x0d // Request of an observer for the blocks decided by a validator
+	decidedBlockMsg     = 0x0e // Decided block sealed with its commit QC, sent to observers
+)
+
+const (
+	// handshakeTimeout is the maximum allowed time for the hotstuff/1 handshake
+	handshakeTimeout = 5 * time.Second
+
+	// handshakeDomain separates handshake proofs from other signed data
+	handshakeDomain = "hotstuff/1 handshake"
+)
+
+var (
+	errNoStatusMsg       = errors.New("no status message")
+	errVersionMismatch   = errors.New("protocol version mismatch")
+	errInvalidHandshake  = errors.New("invalid handshake signature")
+	errUnknownMsgCode    = errors.New("unknown message code")
+	errMsgCodeMismatch   = errors.New("message code does not match message type")
+	errStatusMsgTooLarge = errors.New("status message too large")
+)
+
+// statusData is the hotstuff/1 handshake message. The signature binds the validator
+// address to the devp2p identities of both ends of the connection, so a proof can
+// neither be replayed on another connection nor relayed by a third node.
+type statusData struct {
+	Version   uint32
+	Address   common.Address
+	Signature []byte // Signature over handshakeHash(version, sender ID, receiver ID)
+}
+
+func handshakeHash(version uint32, from, to enode.ID) common.Hash {
+	return crypto.Keccak256Hash([]byte(handshakeDomain), []byte{byte(version)}, from[:], to[:])
+}
+
+// Handshake implements consensus.Handshaker.Handshake. Both ends send their
+// validator address signed over the connection identities, and the remote
+// address is returned once its proof checks out.
+func (s *Backend) Handshake(rw p2p.MsgReadWriter, local, remote enode.ID) (common.Address, error) {
+	version := uint32(consensus.Hotstuff1)
+	sig, err := s.signer.Sign(handshakeHash(version, local, remote))
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	var status statusData
+	errc := make(chan error, 2)
+	go func() {
+		errc <- p2p.Send(rw, statusMsg, &statusData{
+			Version:   version,
+			Address:   s.Address(),
+			Signature: sig,
+		})
+	}()
+	go func() {
+		errc <- readStatus(rw, &status)
+	}()
+	timeout := time.NewTimer(handshakeTimeout)
+	defer timeout.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return common.Address{}, err
+			}
+		case <-timeout.C:
+			return common.Address{}, p2p.DiscReadTimeout
+		}
+	}
+
+	if status.Version != version {
+		return common.Address{}, fmt.Errorf("%w: %d (!= %d)", errVersionMismatch, status.Version, version)
+	}
+	pubkey, err := crypto.SigToPub(handshakeHash(status.Version, remote, local).Bytes(), status.Signature)
+	if err != nil {
+		return common.Address{}, errInvalidHandshake
+	}
+	if crypto.PubkeyToAddress(*pubkey) != status.Address {
+		return common.Address{}, errInvalidHandshake
+	}
+	// observers follow the blocks decided by validators
+	if s.config.Observer {
+		if _, v := s.snap().GetByAddress(status.Address); v != nil {
+			if err := s.SubscribeDecided(rw); err != nil {
+				return common.Address{}, err
+			}
+		}
+	}
+	return status.Address, nil
+}
+
+func readStatus(rw p2p.MsgReadWriter, status *statusData) error {
+	msg, err := rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	defer msg.Discard()
+
+	if msg.Code != statusMsg {
+		return fmt.Errorf("%w: first msg has code %x (!= %x)", errNoStatusMsg, msg.Code, statusMsg)
+	}
+	if msg.Size > 1024 {
+		return errStatusMsgTooLarge
+	}
+	if err := msg.Decode(status); err != nil {
+		return fmt.Errorf("%w: %v", hs.ErrDecodeFailed, err)
+	}
+	return nil
+}
+
+// HighPriority implements consensus.Prioritizer.HighPriority. Prepare messages and
+// fetched proposal transactions carry the proposed block, and decided blocks sent to
+// observers are off the critical path. Every other message is small and on it.
+func (s *Backend) HighPriority(msgcode uint64) bool {
+	return msgcode != uint64(hs.MsgTypePrepare) && msgcode != proposalTxsMsg && msgcode != decidedBlockMsg
+}
+
+// isConsensusMsg reports whether a message code carries a single consensus message
+func isConsensusMsg(msgcode uint64) bool {
+	return msgcode >= uint64(hs.MsgTypeNewView) && msgcode <= uint64(hs.MsgTypeDecide) || msgcode == uint64(hs.MsgTypeSuspect)
+}
+
+// decodeMsgCode returns the hotstuff/1 message code of a consensus message payload
+func decodeMsgCode(payload []byte) (*hs.Message, uint64, error) {
+	msg := new(hs.Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil {
+		return nil, 0, err
+	}
+	if code := uint64(msg.Code); isConsensusMsg(code) {
+		return msg, code, nil
+	}
+	return nil, 0, errUnknownMsgCode
+}
@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var (
+	errNoBeneficiaries     = errors.New("no block reward beneficiaries")
+	errInvalidRewardParent = errors.New("parent commit QC does not certify the parent")
+)
+
+// accumulateRewards credits the block reward in force at the height of header to
+// the beneficiaries of the configured mode, in equal shares. The remainder of the
+// division is not minted.
+func (s *Backend) accumulateRewards(chain consensus.ChainHeaderReader, state *state.StateDB, header *types.Header) error {
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return consensus.ErrUnknownAncestor
+	}
+	reward, beneficiaries, err := s.blockReward(chain.Config(), header, parent)
+	if err != nil || len(beneficiaries) == 0 {
+		return err
+	}
+	share := new(big.Int).Div(reward, big.NewInt(int64(len(beneficiaries))))
+	for _, addr := range beneficiaries {
+		state.AddBalance(addr, share)
+	}
+	s.logger.Trace("Accumulated block rewards", "number", header.Number, "beneficiaries", len(beneficiaries), "share", share)
+	return nil
+}
+
+// blockReward returns the block reward credited with header and its beneficiaries.
+// They only depend on the chain config and the headers, so that every node credits
+// the same accounts, and a mode which cannot be resolved fails the block.
+func (s *Backend) blockReward(config *params.ChainConfig, header, parent *types.Header) (*big.Int, []common.Address, error) {
+	reward, mode, beneficiaries := config.GetHotStuffReward(header.Number)
+	if reward.Sign() <= 0 {
+		return reward, nil, nil
+	}
+	switch mode {
+	case "", params.HotStuffBeneficiaryProposer:
+		return reward, []common.Address{header.Coinbase}, nil
+	case params.HotStuffBeneficiaryFixed:
+		if len(beneficiaries) == 0 {
+			return nil, nil, errNoBeneficiaries
+		}
+		return reward, beneficiaries, nil
+	case params.HotStuffBeneficiaryValidators:
+		signers, err := s.rewardedValidators(parent)
+		if err != nil {
+			return nil, nil, err
+		}
+		return reward, signers, nil
+	}
+	return nil, nil, fmt.Errorf("%w: %q", params.ErrHotStuffBeneficiaryMode, mode)
+}
+
+// rewardedValidators returns the signers of the commit QC recorded in parent, the
+// last one known when the block is built. The signer bitmap of the QC is read with
+// the validators recorded in parent, which the QC certifies. The threshold scheme
+// hides the signers, and every validator recorded in parent is rewarded instead. The
+// genesis block has no QC, and the first block rewards nobody.
+func (s *Backend) rewardedValidators(parent *types.Header) ([]common.Address, error) {
+	if parent.Number.Sign() == 0 {
+		return nil, nil
+	}
+	extra, err := types.ExtractHotstuffExtra(parent)
+	if err != nil {
+		return nil, err
+	}
+	var qc *hs.QuorumCert
+	if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+		return nil, err
+	}
+	if qc == nil || qc.View == nil || qc.View.Height == nil || qc.View.Height.Cmp(parent.Number) != 0 {
+		return nil, errInvalidRewardParent
+	}
+	if s.config.SignatureScheme != hs.MultiBLS {
+		return extra.Validators, nil
+	}
+	return snr.QCSigners(qc, validator.NewSet(extra.Validators, s.config.LeaderPolicy))
+}
@@ -1,8 +1,262 @@
 package backend
 
-import hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
 
-// todo: use snap or reconfig validators group
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	dbKeySnapshotPrefix = "hotstuff-validators-"
+	dbKeyEpochs         = "hotstuff-validator-epochs"
+)
+
+var errUnknownValidators = errors.New("unknown validator set")
+
+// validatorSnapshot is the validator set of an epoch, which governs the heights from
+// Number up to the first height of the next epoch
+type validatorSnapshot struct {
+	Number     uint64              // First height governed by the set
+	Validators []common.Address    // Validators, in the order of the contract
+	BLSKeys    []*snr.BLSPublicKey // BLS public keys of the validators, for the multi-signature scheme
+}
+
+// validatorEpochs indexes the validator snapshots stored in the database
+type validatorEpochs struct {
+	Starts []uint64 // First heights of the epochs, ascending
+	Known  uint64   // Height up to which the validator set of every height was read
+}
+
+func snapshotKey(number uint64) []byte {
+	key := make([]byte, len(dbKeySnapshotPrefix)+8)
+	copy(key, dbKeySnapshotPrefix)
+	binary.BigEndian.PutUint64(key[len(dbKeySnapshotPrefix):], number)
+	return key
+}
+
+// loadSnapshot loads the snapshot of the epoch starting at number from the database
+func loadSnapshot(db ethdb.Database, number uint64) (*validatorSnapshot, error) {
+	blob, err := db.Get(snapshotKey(number))
+	if err != nil {
+		return nil, err
+	}
+	snap := new(validatorSnapshot)
+	if err := rlp.DecodeBytes(blob, snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// store inserts the snapshot into the database
+func (snap *validatorSnapshot) store(db ethdb.Database) error {
+	blob, err := rlp.EncodeToBytes(snap)
+	if err != nil {
+		return err
+	}
+	return db.Put(snapshotKey(snap.Number), blob)
+}
+
+// valSet creates a validator set of the snapshot, with the given selection policy
+func (snap *validatorSnapshot) valSet(policy hs.SelectProposerPolicy) hs.ValidatorSet {
+	return validator.NewSet(snap.Validators, policy)
+}
+
+// loadEpochs loads the index of the validator snapshots, empty if none is stored
+func loadEpochs(db ethdb.Database) (*validatorEpochs, error) {
+	blob, err := db.Get([]byte(dbKeyEpochs))
+	if err != nil {
+		return new(validatorEpochs), nil
+	}
+	epochs := new(validatorEpochs)
+	if err := rlp.DecodeBytes(blob, epochs); err != nil {
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// store inserts the index into the database
+func (epochs *validatorEpochs) store(db ethdb.Database) error {
+	blob, err := rlp.EncodeToBytes(epochs)
+	if err != nil {
+		return err
+	}
+	return db.Put([]byte(dbKeyEpochs), blob)
+}
+
+// epochOf returns the first height of the epoch containing number, false if number
+// precedes the first epoch
+func (epochs *validatorEpochs) epochOf(number uint64) (uint64, bool) {
+	i := sort.Search(len(epochs.Starts), func(i int) bool { return epochs.Starts[i] > number })
+	if i == 0 {
+		return 0, false
+	}
+	return epochs.Starts[i-1], true
+}
+
+// snap returns a copy of the validator set of the current height
 func (s *Backend) snap() hs.ValidatorSet {
-	return s.valset.Copy()
+	return s.validatorSet().Copy()
+}
+
+// validatorSet returns the validator set of the current height. The set is replaced
+// rather than changed when the validators change, and must not be changed.
+func (s *Backend) validatorSet() hs.ValidatorSet {
+	s.valsetMu.RLock()
+	defer s.valsetMu.RUnlock()
+	return s.valset
+}
+
+// validators returns the validator set governing the child of parent. A set managed
+// by a contract is taken from the snapshot of its epoch, or read at the state of
+// parent if the set of that height was never read.
+func (s *Backend) validators(chain consensus.ChainHeaderReader, parent *types.Header, abort <-chan struct{}) (hs.ValidatorSet, error) {
+	if s.validatorContract == nil {
+		return s.snap(), nil
+	}
+	snap, err := s.snapshot(chain, parent, abort)
+	if err != nil {
+		return nil, err
+	}
+	return snap.valSet(s.validatorSet().Policy()), nil
+}
+
+// validatorsOf returns the validator set governing the given height
+func (s *Backend) validatorsOf(height uint64) (hs.ValidatorSet, error) {
+	if s.validatorContract == nil || height == 0 {
+		return s.snap(), nil
+	}
+	if s.chain == nil {
+		return nil, errUnknownValidators
+	}
+	parent := s.chain.GetHeaderByNumber(height - 1)
+	if parent == nil {
+		return nil, errUnknownValidators
+	}
+	return s.validators(s.chain, parent, nil)
+}
+
+// snapshot returns the validator snapshot governing the child of parent, read from
+// the validator contract and stored if it starts a new epoch
+func (s *Backend) snapshot(chain consensus.ChainHeaderReader, parent *types.Header, abort <-chan struct{}) (*validatorSnapshot, error) {
+	number := parent.Number.Uint64() + 1
+	if snap, err := s.knownSnapshot(number); snap != nil || err != nil {
+		return snap, err
+	}
+
+	// the contract is read without the lock, as the state may be waited for
+	snap, err := s.validatorContract.snapshotAt(chain, parent, abort)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.registerKeys(snap); err != nil {
+		return nil, err
+	}
+
+	s.epochsMu.Lock()
+	defer s.epochsMu.Unlock()
+
+	// the epochs start at the first height read and are only extended by the next
+	// height, so that an epoch covers every height up to the next one
+	if len(s.epochs.Starts) > 0 && number != s.epochs.Known+1 {
+		return snap, nil
+	}
+	start, ok := s.epochs.epochOf(number)
+	if ok {
+		last, err := s.epochSnapshot(start)
+		if err != nil {
+			return nil, err
+		}
+		ok = sameMembers(last.Validators, snap.Validators)
+	}
+	if !ok {
+		if err := snap.store(s.db); err != nil {
+			return nil, err
+		}
+		s.epochs.Starts = append(s.epochs.Starts, number)
+		s.recents.Add(number, snap)
+	}
+	s.epochs.Known = number
+	if err := s.epochs.store(s.db); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// knownSnapshot returns the stored snapshot governing the given height, or nil if
+// the set of that height was never read
+func (s *Backend) knownSnapshot(number uint64) (*validatorSnapshot, error) {
+	s.epochsMu.Lock()
+	defer s.epochsMu.Unlock()
+
+	if s.epochs == nil {
+		epochs, err := loadEpochs(s.db)
+		if err != nil {
+			return nil, err
+		}
+		s.epochs = epochs
+	}
+	if number > s.epochs.Known {
+		return nil, nil
+	}
+	start, ok := s.epochs.epochOf(number)
+	if !ok {
+		return nil, nil
+	}
+	return s.epochSnapshot(start)
+}
+
+// epochSnapshot returns the snapshot of the epoch starting at number, the caller must
+// hold epochsMu
+func (s *Backend) epochSnapshot(number uint64) (*validatorSnapshot, error) {
+	if snap, ok := s.recents.Get(number); ok {
+		return snap.(*validatorSnapshot), nil
+	}
+	snap, err := loadSnapshot(s.db, number)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.registerKeys(snap); err != nil {
+		return nil, err
+	}
+	s.recents.Add(number, snap)
+	return snap, nil
+}
+
+// registerKeys registers the BLS public keys of a snapshot with the multi-signature
+// scheme, whose votes and QCs are verified with them
+func (s *Backend) registerKeys(snap *validatorSnapshot) error {
+	multi := s.multiSigner()
+	if multi == nil {
+		return nil
+	}
+	for _, key := range snap.BLSKeys {
+		if err := multi.AddPublicKey(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sameMembers reports whether two validator lists hold the same validators, in any
+// order
+func sameMembers(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := validatorMap(a)
+	for _, addr := range b {
+		if !set[addr] {
+			return false
+		}
+	}
+	return true
 }
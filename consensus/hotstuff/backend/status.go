@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// commitStatus tracks the last block committed by the engine
+type commitStatus struct {
+	mu           sync.RWMutex
+	time         time.Time
+	participants int
+}
+
+// qcSigners is implemented by signing schemes whose QCs identify their signers,
+// e.g. snr.MultiSigner
+type qcSigners interface {
+	Signers(qc *hs.QuorumCert) ([]common.Address, error)
+}
+
+// recordCommit records the time and the commit QC participants of a committed block
+func (s *Backend) recordCommit(header *types.Header) {
+	participants := 0
+	if extra, err := types.ExtractHotstuffExtra(header); err == nil {
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err == nil {
+			participants = s.QCParticipants(qc)
+		}
+	}
+
+	s.commitStatus.mu.Lock()
+	s.commitStatus.time = time.Now()
+	s.commitStatus.participants = participants
+	s.commitStatus.mu.Unlock()
+}
+
+// scheme returns the signing scheme of the engine, behind a remote signer if any
+func (s *Backend) scheme() hs.Signer {
+	if remote, ok := s.signer.(*snr.RemoteSigner); ok {
+		return remote.Signer
+	}
+	return s.signer
+}
+
+// QCParticipants returns the voting power of the validators which signed a QC, in
+// the validator set of its height. A threshold signature hides its signers, and is
+// always recovered from a quorum of shares.
+func (s *Backend) QCParticipants(qc *hs.QuorumCert) int {
+	if qc == nil || qc.View == nil || qc.View.Height == nil {
+		return 0
+	}
+	valSet, err := s.validatorsOf(qc.View.Height.Uint64())
+	if err != nil {
+		return 0
+	}
+	signer, ok := s.scheme().(qcSigners)
+	if !ok {
+		return valSet.Q()
+	}
+	signers, err := signer.Signers(qc)
+	if err != nil {
+		return 0
+	}
+	power := 0
+	for _, addr := range signers {
+		if _, val := valSet.GetByAddress(addr); val != nil {
+			power += val.Weight()
+		}
+	}
+	return power
+}
+
+// Status returns a snapshot of the consensus state of the node
+func (s *Backend) Status() *hs.Status {
+	height, round := s.core.CurrentSequence()
+	status := &hs.Status{
+		Height:       height,
+		Round:        round,
+		RoundChanges: s.core.RoundChanges(),
+	}
+	if proposer := s.validatorSet().GetProposer(); proposer != nil {
+		status.Leader = proposer.Address()
+	}
+	_, val := s.validatorSet().GetByAddress(s.Address())
+	status.IsValidator = val != nil
+
+	s.commitStatus.mu.RLock()
+	status.LastCommit = s.commitStatus.time
+	status.QCParticipants = s.commitStatus.participants
+	s.commitStatus.mu.RUnlock()
+
+	return status
+}
@@ -0,0 +1,186 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend/contract"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/mps"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+const (
+	// contractCallGas is the gas available to a call of the validator contract
+	contractCallGas = 50000000
+
+	// stateRetryInterval is the interval at which the state of a parent verified in
+	// the same batch of headers is looked up again
+	stateRetryInterval = 10 * time.Millisecond
+)
+
+var (
+	errEmptyValidatorSet = errors.New("empty validator set")
+	errUnknownState      = errors.New("validator contract state unavailable")
+)
+
+// stateReader is implemented by chains serving the state of their blocks, e.g.
+// core.BlockChain
+type stateReader interface {
+	StateAt(root common.Hash) (*state.StateDB, mps.PrivateStateRepository, error)
+}
+
+// validatorContract reads the validator set managed by a contract implementing
+// contract/HotStuffValidatorContractInterface.sol
+type validatorContract struct {
+	address common.Address
+	keys    bool // Whether the BLS public keys of the validators are read as well
+}
+
+func newValidatorContract(config *hs.Config) *validatorContract {
+	if config.ValidatorContract == (common.Address{}) {
+		return nil
+	}
+	return &validatorContract{
+		address: config.ValidatorContract,
+		keys:    config.SignatureScheme == hs.MultiBLS,
+	}
+}
+
+// snapshotAt reads the validators governing the child of parent from the contract,
+// at the state of parent. Within a batch of headers the state of a parent verified
+// earlier in the batch is written after its header is verified, and is waited for
+// until abort is closed.
+func (c *validatorContract) snapshotAt(chain consensus.ChainHeaderReader, parent *types.Header, abort <-chan struct{}) (*validatorSnapshot, error) {
+	reader, ok := chain.(stateReader)
+	if !ok {
+		return nil, errUnknownState
+	}
+	statedb, _, err := reader.StateAt(parent.Root)
+	for err != nil && abort != nil {
+		select {
+		case <-abort:
+			return nil, errUnknownState
+		case <-time.After(stateRetryInterval):
+			statedb, _, err = reader.StateAt(parent.Root)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnknownState, err)
+	}
+	caller, err := contract.NewHotStuffValidatorContractInterfaceCaller(c.address, &stateCaller{chain: chain, header: parent, state: statedb})
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &validatorSnapshot{Number: parent.Number.Uint64() + 1}
+	opts := &bind.CallOpts{BlockNumber: parent.Number}
+	if snap.Validators, err = caller.GetValidators(opts); err != nil {
+		return nil, err
+	}
+	if len(snap.Validators) == 0 {
+		return nil, errEmptyValidatorSet
+	}
+	if !c.keys {
+		return snap, nil
+	}
+	blsKeys, err := caller.GetBLSPublicKeys(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(blsKeys.PublicKeys) != len(snap.Validators) || len(blsKeys.Proofs) != len(snap.Validators) {
+		return nil, fmt.Errorf("%d BLS public keys and %d proofs for %d validators", len(blsKeys.PublicKeys), len(blsKeys.Proofs), len(snap.Validators))
+	}
+	snap.BLSKeys = make([]*snr.BLSPublicKey, len(snap.Validators))
+	for i, addr := range snap.Validators {
+		snap.BLSKeys[i] = &snr.BLSPublicKey{
+			Address:   addr,
+			PublicKey: blsKeys.PublicKeys[i],
+			Proof:     blsKeys.Proofs[i],
+		}
+	}
+	return snap, nil
+}
+
+// stateCaller serves the calls of the validator contract from the state of a block,
+// so that every node reads the same validators whatever its RPC endpoints
+type stateCaller struct {
+	chain  consensus.ChainHeaderReader
+	header *types.Header
+	state  *state.StateDB
+}
+
+func (c *stateCaller) CodeAt(ctx context.Context, contract common.Address, number *big.Int) ([]byte, error) {
+	return c.state.GetCode(contract), nil
+}
+
+func (c *stateCaller) CallContract(ctx context.Context, call ethereum.CallMsg, number *big.Int) ([]byte, error) {
+	if call.To == nil {
+		return nil, errors.New("contract creation")
+	}
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash: func(n uint64) common.Hash {
+			if header := c.chain.GetHeaderByNumber(n); header != nil {
+				return header.Hash()
+			}
+			return common.Hash{}
+		},
+		Coinbase:    c.header.Coinbase,
+		GasLimit:    c.header.GasLimit,
+		BlockNumber: new(big.Int).Set(c.header.Number),
+		Time:        new(big.Int).SetUint64(c.header.Time),
+		Difficulty:  new(big.Int).Set(c.header.Difficulty),
+	}
+	txCtx := vm.TxContext{Origin: call.From, GasPrice: new(big.Int)}
+	evm := vm.NewEVM(blockCtx, txCtx, c.state, c.state, c.chain.Config(), vm.Config{})
+	ret, _, err := evm.StaticCall(vm.AccountRef(call.From), *call.To, call.Data, contractCallGas)
+	return ret, err
+}
+
+// multiSigner returns the signer of the multi-signature scheme, which needs the
+// BLS public keys of new validators, or nil
+func (s *Backend) multiSigner() *snr.MultiSigner {
+	multi, _ := s.scheme().(*snr.MultiSigner)
+	return multi
+}
+
+// UpdateValidators implements hs.Backend.UpdateValidators. The validator set is read
+// from the validator contract at the state of parent, and replaces the current set
+// if its validators changed. Sets are never changed in place, as the verifier workers
+// and the RPC services read them concurrently.
+func (s *Backend) UpdateValidators(parent *types.Header) (hs.ValidatorSet, error) {
+	if s.validatorContract == nil {
+		return nil, nil
+	}
+	snap, err := s.snapshot(s.chain, parent, nil)
+	if err != nil {
+		return nil, err
+	}
+	current := s.validatorSet()
+	if sameMembers(current.AddressList(), snap.Validators) {
+		return nil, nil
+	}
+	next := snap.valSet(current.Policy())
+
+	s.valsetMu.Lock()
+	s.valset = next
+	s.valsetMu.Unlock()
+	if multi := s.multiSigner(); multi != nil {
+		multi.SetValidators(next)
+	}
+	s.logger.Info("Updated validator set", "number", snap.Number, "validators", next.Size(), "previous", current.Size())
+	return next, nil
+}
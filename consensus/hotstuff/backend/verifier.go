@@ -0,0 +1,201 @@
+package backend
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// inmemoryQCs is the number of recent QCs whose signature is known valid
+	inmemoryQCs = 256
+
+	// verifyQueueSize is the number of received messages each verifier worker queues
+	verifyQueueSize = 256
+
+	// verifyQueueTimeout is how long a peer handler waits for room in a full verifier
+	// queue before the message is dropped
+	verifyQueueTimeout = 100 * time.Millisecond
+)
+
+// qcCache authenticates QCs with the wrapped signer once, and accepts the QCs whose
+// signature verified before at once, e.g. the same highQC carried by the NewView
+// messages of all validators. Concurrent checks of the same QC wait for the first one.
+type qcCache struct {
+	hs.Signer
+
+	mu       sync.Mutex
+	verified *lru.ARCCache                 // Hashes of the QCs whose signature verified
+	pending  map[common.Hash]chan struct{} // QCs being verified, closed when done
+}
+
+func newQCCache(signer hs.Signer) *qcCache {
+	verified, _ := lru.NewARC(inmemoryQCs)
+	return &qcCache{
+		Signer:   signer,
+		verified: verified,
+		pending:  make(map[common.Hash]chan struct{}),
+	}
+}
+
+// AuthQC implements hs.Signer.AuthQC
+func (c *qcCache) AuthQC(qc *hs.QuorumCert) error {
+	hash := hs.RLPHash(qc)
+
+	c.mu.Lock()
+	for {
+		if c.verified.Contains(hash) {
+			c.mu.Unlock()
+			return nil
+		}
+		wait, ok := c.pending[hash]
+		if !ok {
+			break
+		}
+		// verify again if the pending check failed
+		c.mu.Unlock()
+		<-wait
+		c.mu.Lock()
+	}
+	done := make(chan struct{})
+	c.pending[hash] = done
+	c.mu.Unlock()
+
+	err := c.Signer.AuthQC(qc)
+
+	c.mu.Lock()
+	if err == nil {
+		c.verified.Add(hash, true)
+	}
+	delete(c.pending, hash)
+	c.mu.Unlock()
+	close(done)
+	return err
+}
+
+// verifier decodes received consensus messages, and checks their signature and the
+// QC they carry in parallel, off the core event loop. The messages of a validator are
+// handled by the same worker, so that the core receives them in the order they came.
+type verifier struct {
+	backend *Backend
+	queues  []chan hs.MessageEvent
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newVerifier(backend *Backend, workers int) *verifier {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	v := &verifier{
+		backend: backend,
+		queues:  make([]chan hs.MessageEvent, workers),
+		quit:    make(chan struct{}),
+	}
+	for i := range v.queues {
+		v.queues[i] = make(chan hs.MessageEvent, verifyQueueSize)
+		v.wg.Add(1)
+		go v.loop(v.queues[i])
+	}
+	return v
+}
+
+// submit queues a received message for verification and delivery to the core. If
+// the queue of the sender is full, the peer handler waits for a moment and drops the
+// message if the worker is still busy, rather than posting it past the messages queued
+// before it.
+func (v *verifier) submit(ev hs.MessageEvent) {
+	queue := v.queues[int(ev.Src[len(ev.Src)-1])%len(v.queues)]
+	select {
+	case queue <- ev:
+		return
+	case <-v.quit:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(verifyQueueTimeout)
+	defer timer.Stop()
+	select {
+	case queue <- ev:
+	case <-v.quit:
+	case <-timer.C:
+		v.backend.logger.Debug("Verifier queue full, dropping message", "src", ev.Src)
+	}
+}
+
+func (v *verifier) loop(queue chan hs.MessageEvent) {
+	defer v.wg.Done()
+
+	for {
+		select {
+		case ev := <-queue:
+			v.verify(&ev)
+			v.backend.eventMux.Post(ev)
+		case <-v.quit:
+			return
+		}
+	}
+}
+
+// verify decodes the message of the event and checks its signature, then checks the
+// signature of the QC it carries, which the core finds in the QC cache afterwards.
+// Invalid messages are left for the core to decode, reject and log. The signature is
+// checked against the validator set taken when the check starts, which is replaced
+// rather than changed when the validators change. Messages decoded already, e.g.
+// relayed over the overlay tree, only have their QC checked.
+func (v *verifier) verify(ev *hs.MessageEvent) {
+	var (
+		s      = v.backend
+		valSet = s.validatorSet()
+		msg    = ev.Msg
+	)
+	if msg == nil {
+		msg = new(hs.Message)
+		err := msg.FromPayload(ev.Src, ev.Payload, func(hash common.Hash, sig []byte) (common.Address, error) {
+			return s.signer.CheckSignature(valSet, hash, sig)
+		})
+		if err != nil {
+			return
+		}
+	}
+	if qc := messageQC(msg); qc != nil && qc.View != nil {
+		if err := s.qcCache.AuthQC(qc); err != nil {
+			s.logger.Trace("Invalid QC in message", "msgCode", msg.Code, "src", ev.Src, "err", err)
+		}
+	}
+	ev.Msg = msg
+}
+
+// stop terminates the workers, the messages still queued are dropped
+func (v *verifier) stop() {
+	close(v.quit)
+	v.wg.Wait()
+}
+
+// messageQC returns the QC carried by a consensus message, or nil for votes and
+// messages which cannot be decoded
+func messageQC(msg *hs.Message) *hs.QuorumCert {
+	switch msg.Code {
+	case hs.MsgTypeNewView, hs.MsgTypePreCommit, hs.MsgTypeCommit:
+		var qc *hs.QuorumCert
+		if err := msg.Decode(&qc); err == nil {
+			return qc
+		}
+	case hs.MsgTypePrepare:
+		var subject *hs.PackagedQC
+		if err := msg.Decode(&subject); err == nil && subject != nil {
+			return subject.QC
+		}
+	case hs.MsgTypeDecide:
+		var diploma *hs.Diploma
+		if err := msg.Decode(&diploma); err == nil && diploma != nil {
+			return diploma.CommitQC
+		}
+	}
+	return nil
+}
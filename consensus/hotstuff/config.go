@@ -1,11 +1,32 @@
 package hotstuff
 
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
 type SelectProposerPolicy string
 
 const (
 	RoundRobin SelectProposerPolicy = "RoundRobin"
 	Sticky     SelectProposerPolicy = "Sticky"
 	VRF        SelectProposerPolicy = "VRF"
+	Reputation SelectProposerPolicy = "Reputation"
+)
+
+type SignatureScheme string
+
+const (
+	ThresholdBLS SignatureScheme = "Threshold" // (t, n)-threshold BLS over dealt key shares
+	MultiBLS     SignatureScheme = "MultiSig"  // BLS multi-signature over per-validator keys
+)
+
+type Topology string
+
+const (
+	Star Topology = "Star" // Leader exchanges every message with every replica directly
+	Tree Topology = "Tree" // Kauri-style overlay tree rooted at the leader
 )
 
 type FaultyMode string
@@ -17,16 +38,77 @@ const (
 	BadDecideBadBlock    FaultyMode = "GoodDecideBadBlock"   // Leader faulty-seals block but has a good decide
 )
 
+type CensorshipAction string
+
+const (
+	FlagCensorship       CensorshipAction = "Flag"       // Leaders suspected of censorship are logged, counted and reported
+	ViewChangeCensorship CensorshipAction = "ViewChange" // Replicas also refuse the proposal of a suspected leader and change the view early
+)
+
 type Config struct {
-	RequestTimeout uint64               `toml:",omitempty"` // The timeout for each HotStuff round in milliseconds.
-	BlockPeriod    uint64               `toml:",omitempty"` // Default minimum difference between two consecutive block's timestamps in second for basic hotstuff and mill-seconds for event-driven
-	LeaderPolicy   SelectProposerPolicy `toml:",omitempty"` // The policy for speaker selection
-	FaultyMode     FaultyMode           `toml:",omitempty"` // The faulty node indicates the faulty node's behavior
+	RequestTimeout   uint64               `toml:",omitempty"` // The timeout for each HotStuff round in milliseconds.
+	BlockPeriod      uint64               `toml:",omitempty"` // Default minimum difference between two consecutive block's timestamps in second for basic hotstuff and mill-seconds for event-driven
+	BlockPeriodMs    uint64               `toml:",omitempty"` // Minimum difference between two consecutive block's timestamps in milliseconds, overrides BlockPeriod if set
+	EmptyBlockPeriod uint64               `toml:",omitempty"` // Minimum difference between the timestamps of a block and an empty child in seconds, ignored unless longer than the block period
+	LeaderPolicy     SelectProposerPolicy `toml:",omitempty"` // The policy for speaker selection
+	FaultyMode       FaultyMode           `toml:",omitempty"` // The faulty node indicates the faulty node's behavior
+	ReputationWindow uint64               `toml:",omitempty"` // Number of recent blocks scored by the Reputation leader policy
+	SignatureScheme  SignatureScheme      `toml:",omitempty"` // The BLS scheme used to sign votes and QCs
+	Topology         Topology             `toml:",omitempty"` // The overlay used to disseminate proposals and collect votes
+	TreeFanout       uint64               `toml:",omitempty"` // Number of children per node in the Tree topology, sqrt(n) if 0
+	CompactProposal  bool                 `toml:",omitempty"` // Send transaction hashes instead of full blocks in Prepare messages
+	PipelinedBuild   bool                 `toml:",omitempty"` // The next proposer builds its block on the locked block while the locked block is decided
+	SignerTimeout    uint64               `toml:",omitempty"` // The timeout for each call to a remote signer in milliseconds, 0 for none
+	VerifyWorkers    uint64               `toml:",omitempty"` // Goroutines checking the signatures and QCs of received messages off the core event loop, the number of CPUs if 0
+	CensorshipViews  uint64               `toml:",omitempty"` // Proposals with spare gas a pending transaction may be left out of before the leader is suspected, 0 disables detection
+	CensorshipAction CensorshipAction     `toml:",omitempty"` // What replicas do about a leader suspected of censorship
+	DecideObservers  bool                 `toml:",omitempty"` // Forward decided blocks to the observer peers subscribed to them
+	Observer         bool                 `toml:",omitempty"` // Subscribe to the blocks decided by validator peers and import them at once
+	RecordFile       string               `toml:",omitempty"` // File the events handled by the core are recorded to for replay, none if empty
+	RecordFileSize   uint64               `toml:",omitempty"` // Size in megabytes at which the recording rotates, 64 if 0
+	TimelineFile     string               `toml:",omitempty"` // File the events of every view are appended to as JSON lines, none if empty
+
+	HealthCommitPeriods uint64 `toml:",omitempty"` // Block periods without a commit after which the node reports unhealthy, 0 disables the check
+	HealthMaxRound      uint64 `toml:",omitempty"` // Round of a height from which the node reports unhealthy, 0 disables the check
+	HealthPeers         uint64 `toml:",omitempty"` // Validators which must be connected, counting the node itself, besides the voting power of a quorum, none if 0
+
+	ValidatorContract common.Address `toml:",omitempty"` // Contract managing the validator set, read at the parent state of every block
 }
 
 var DefaultBasicConfig = &Config{
-	RequestTimeout: 6000,
-	BlockPeriod:    3,
-	LeaderPolicy:   RoundRobin,
-	FaultyMode:     Disabled,
+	RequestTimeout:   6000,
+	BlockPeriod:      3,
+	LeaderPolicy:     RoundRobin,
+	FaultyMode:       Disabled,
+	ReputationWindow: 100,
+	SignatureScheme:  ThresholdBLS,
+	Topology:         Star,
+	TreeFanout:       0,
+	CompactProposal:  false,
+	PipelinedBuild:   false,
+	SignerTimeout:    2000,
+	VerifyWorkers:    0,
+	CensorshipViews:  0,
+	CensorshipAction: FlagCensorship,
+
+	HealthCommitPeriods: 10,
+	HealthMaxRound:      5,
+	HealthPeers:         0,
+}
+
+// Period returns the minimum time between two consecutive blocks
+func (c *Config) Period() time.Duration {
+	if c.BlockPeriodMs != 0 {
+		return time.Duration(c.BlockPeriodMs) * time.Millisecond
+	}
+	return time.Duration(c.BlockPeriod) * time.Second
+}
+
+// EmptyPeriod returns the minimum time between a block and an empty child, which
+// is never shorter than the block period
+func (c *Config) EmptyPeriod() time.Duration {
+	if period := time.Duration(c.EmptyBlockPeriod) * time.Second; period > c.Period() {
+		return period
+	}
+	return c.Period()
 }
@@ -2,6 +2,7 @@ package core
 
 import (
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 )
 
 // handlePreCommitVote implements the Commit phase's leader portion (see BHS specs)
@@ -41,7 +42,7 @@ func (c *Core) handlePreCommitVote(data *hs.Message) error {
 
 	logger.Trace("handlePreCommitVote", "msgCode", code, "src", src, "hash", vote)
 
-	if size := c.current.PreCommitVoteSize(); size >= c.valSet.Q() && c.currentState() < hs.StatePreCommitted {
+	if weight := c.current.PreCommitVoteWeight(); weight >= c.valSet.Q() && c.currentState() < hs.StatePreCommitted {
 		lockQC, err := c.messagesToQC(code)
 		if err != nil {
 			logger.Trace("Failed to assemble lockQC", "msgCode", code, "err", err)
@@ -51,7 +52,8 @@ func (c *Core) handlePreCommitVote(data *hs.Message) error {
 			logger.Trace("Failed to accept lockQC", "msgCode", code, "err", err)
 			return err
 		}
-		logger.Trace("acceptLockQC", "msgCode", code, "msgSize", size)
+		logger.Trace("acceptLockQC", "msgCode", code, "msgWeight", weight)
+		c.mark(timeline.Quorum, code.String())
 
 		c.sendCommit(lockQC)
 	}
@@ -129,5 +131,31 @@ func (c *Core) acceptLockQC(qc *hs.QuorumCert) error {
 		return err
 	}
 	c.current.SetState(hs.StatePreCommitted)
+
+	if c.config.PipelinedBuild && c.isNextProposer() {
+		c.speculate()
+	}
 	return nil
 }
+
+// isNextProposer predicts whether the node proposes at round 0 of the next height,
+// if the locked block is decided in the current round. The prediction is skipped
+// under the Reputation policy, whose scores change with the decided block.
+func (c *Core) isNextProposer() bool {
+	if c.valSet.Policy() == hs.Reputation || c.valSet.GetProposer() == nil {
+		return false
+	}
+	valSet := c.valSet.Copy()
+	valSet.CalcProposer(c.valSet.GetProposer().Address(), c.HeightU64()+1, 0)
+	return valSet.IsProposer(c.backend.Address())
+}
+
+// speculate lets the miner build the next block on the locked block, which it
+// throws away if another block is decided
+func (c *Core) speculate() {
+	executed, locked := c.current.executed, c.current.LockedBlock()
+	if executed == nil || executed.Block == nil || locked == nil || executed.Block.Hash() != locked.Hash() {
+		return
+	}
+	c.backend.Speculate(executed)
+}
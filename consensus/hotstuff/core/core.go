@@ -9,12 +9,18 @@ import (
 	"github.com/ethereum/go-ethereum/common/prque"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff/faulty"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/recorder"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
+// censorshipMeter counts the proposals whose leader was suspected of censorship
+var censorshipMeter = metrics.NewRegisteredMeter("consensus/hotstuff/core/censorship", nil)
+
 type Core struct {
 	db     ethdb.Database
 	config *hs.Config
@@ -33,10 +39,17 @@ type Core struct {
 	finalCommittedSub *event.TypeMuxSubscription
 
 	roundChangeTimer *time.Timer
+	proposeTimer     *time.Timer
+	proposeView      *hs.View // View of the proposal waiting for its block timestamp
 
 	pendingRequests   *prque.Prque
 	pendingRequestsMu *sync.Mutex
 
+	roundChanges uint64 // Number of timed out rounds, accessed atomically
+
+	recorder *recorder.Recorder // Recording of the handled events, nil if disabled
+	timeline *timeline.Timeline // Events of the recent views
+
 	validateFn func(common.Hash, []byte) (common.Address, error)
 	isRunning  bool
 }
@@ -56,6 +69,7 @@ func New(backend hs.Backend, config *hs.Config, signer hs.Signer, db ethdb.Datab
 		backlogs:          newBackLog(),
 		pendingRequests:   prque.New(nil),
 		pendingRequestsMu: new(sync.Mutex),
+		timeline:          timeline.New(backend.Address()),
 	}
 	c.validateFn = c.checkValidatorSignature
 
@@ -109,8 +123,25 @@ func (c *Core) startNewRound(round *big.Int) {
 		newView.Round = new(big.Int).Set(round)
 	}
 
+	// follow validator set changes once per height, before the proposer is chosen
+	if !changeView {
+		valSet, err := c.backend.UpdateValidators(lastProposal.Header())
+		if err != nil {
+			logger.Error("Failed to update validator set", "number", lastProposal.NumberU64(), "err", err)
+			return
+		}
+		if valSet != nil {
+			c.valSet = valSet
+		}
+	}
+
+	// score leaders on the chain history once per height
+	if !changeView && c.valSet.Policy() == hs.Reputation {
+		c.valSet.UpdateReputation(c.backend.CommitHistory(lastProposal.NumberU64(), c.config.ReputationWindow))
+	}
+
 	// calculate new proposal and init round state
-	c.valSet.CalcProposer(lastProposer, newView.Round.Uint64())
+	c.valSet.CalcProposer(lastProposer, newView.Height.Uint64(), newView.Round.Uint64())
 
 	// update smr and try to unlock at the round0
 	if err := c.updateRoundState(lastProposal, newView); err != nil {
@@ -124,6 +155,8 @@ func (c *Core) startNewRound(round *big.Int) {
 		}
 	}
 
+	c.mark(timeline.Start, "")
+
 	logger.Debug("New round", "state", c.currentState(), "newView", newView, "new_proposer", c.valSet.GetProposer(), "size", c.valSet.Size(), "IsProposer", c.IsProposer())
 
 	// stop last timer and regenerate new timer
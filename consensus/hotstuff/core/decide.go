@@ -4,8 +4,8 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/consensus"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -57,7 +57,7 @@ func (c *Core) handleCommitVote(data *hs.Message) error {
 	logger.Trace("handleCommitVote", "msgCode", code, "src", src, "hash", vote)
 
 	// assemble committed signatures to reorg the locked block, and create `commitQC` at the same time.
-	if size := c.current.CommitVoteSize(); size >= c.valSet.Q() && c.currentState() == hs.StatePreCommitted {
+	if weight := c.current.CommitVoteWeight(); weight >= c.valSet.Q() && c.currentState() == hs.StatePreCommitted {
 		commitQC, err := c.messagesToQC(code)
 		if err != nil {
 			logger.Trace("Failed to assemble commitQC", "msgCode", code, "err", err)
@@ -68,11 +68,13 @@ func (c *Core) handleCommitVote(data *hs.Message) error {
 			logger.Trace("Failed to assemble committed proposal", "msgCode", code, "err", err)
 			return err
 		}
+		c.mark(timeline.Sealed, "")
 		if err := c.acceptCommitQC(sealedBlock, commitQC); err != nil {
 			logger.Trace("Failed to accept commitQC", "msgCode", code, "err", err)
 			return err
 		}
-		logger.Trace("acceptCommit", "msgCode", code, "msgSize", size)
+		logger.Trace("acceptCommit", "msgCode", code, "msgWeight", weight)
+		c.mark(timeline.Quorum, code.String())
 
 		c.sendDecide(sealedBlock.Hash(), commitQC)
 	}
@@ -171,6 +173,7 @@ func (c *Core) handleDecide(data *hs.Message) error {
 			logger.Trace("Failed to assemble committed proposal", "msgCode", code, "err", err)
 			return err
 		}
+		c.mark(timeline.Sealed, "")
 		if err := c.acceptCommitQC(sealedBlock, commitQC); err != nil {
 			logger.Trace("Failed to accept commitQC", "msgCode", code, "err", err)
 			return err
@@ -205,20 +208,18 @@ func (c *Core) commit(sealedBlock *types.Block) error {
 		return fmt.Errorf("expect locked block %v, got %v", lockedBlock.Hash(), sealedBlock.Hash())
 	}
 
+	// the block is normally executed in Prepare already
 	if c.current.executed == nil || c.current.executed.Block == nil || c.current.executed.Block.Hash() != sealedBlock.Hash() {
-		if c.IsProposer() {
-			c.current.executed = &consensus.ExecutedBlock{Block: sealedBlock}
-		} else {
-			executed, err := c.backend.ExecuteBlock(sealedBlock)
-			if err != nil {
-				return fmt.Errorf("failed to execute block %v, err: %v", sealedBlock.Hash(), err)
-			}
-			executed.Block = sealedBlock
-			c.current.executed = executed
+		if err := c.executeBlock(sealedBlock); err != nil {
+			return fmt.Errorf("failed to execute block %v, err: %v", sealedBlock.Hash(), err)
 		}
 	}
 
-	return c.backend.Commit(c.current.executed)
+	if err := c.backend.Commit(c.current.executed); err != nil {
+		return err
+	}
+	c.mark(timeline.Committed, "")
+	return nil
 }
 
 // handleFinalCommitted start new round if consensus engine accept notify signal from miner.worker.
@@ -2,9 +2,13 @@ package core
 
 import (
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/recorder"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 // Start implements core.Engine.Start
@@ -12,6 +16,23 @@ func (c *Core) Start() error {
 	c.isRunning = true
 	c.current = nil
 
+	if c.config.RecordFile != "" {
+		rec, err := recorder.New(c.config.RecordFile, c.config.RecordFileSize)
+		if err != nil {
+			return err
+		}
+		c.recorder = rec
+		if head, _ := c.backend.LastProposal(); head != nil {
+			data, _ := rlp.EncodeToBytes(head.Header())
+			c.recorder.Record(recorder.Start, c.Address(), data)
+		}
+	}
+	if c.config.TimelineFile != "" {
+		if err := c.timeline.Open(c.config.TimelineFile); err != nil {
+			return err
+		}
+	}
+
 	c.subscribeEvents()
 	go c.handleEvents()
 
@@ -27,6 +48,12 @@ func (c *Core) Stop() error {
 	c.unsubscribeEvents()
 	c.isRunning = false
 
+	if err := c.recorder.Close(); err != nil {
+		c.logger.Warn("Failed to close consensus recording", "err", err)
+	}
+	if err := c.timeline.Close(); err != nil {
+		c.logger.Warn("Failed to close consensus timeline", "err", err)
+	}
 	return nil
 }
 
@@ -56,6 +83,14 @@ func (c *Core) CurrentSequence() (uint64, uint64) {
 	return view.HeightU64(), view.RoundU64()
 }
 
+func (c *Core) RoundChanges() uint64 {
+	return atomic.LoadUint64(&c.roundChanges)
+}
+
+func (c *Core) Timeline(height uint64) []*timeline.View {
+	return c.timeline.Get(height)
+}
+
 // ----------------------------------------------------------------------------
 
 // Subscribe both internal and external events
@@ -66,6 +101,7 @@ func (c *Core) subscribeEvents() {
 		// internal events
 		hs.MessageEvent{},
 		backlogEvent{},
+		proposeEvent{},
 	)
 	c.timeoutSub = c.backend.EventMux().Subscribe(
 		timeoutEvent{},
@@ -88,13 +124,27 @@ func (c *Core) handleEvents() {
 			// A real Event arrived, process interesting content
 			switch ev := event.Data.(type) {
 			case hs.RequestEvent:
+				c.record(recorder.Request, common.Address{}, ev.Block)
 				c.handleRequest(&hs.Request{Block: ev.Block})
 
 			case hs.MessageEvent:
-				c.handleMsg(ev.Src, ev.Payload)
+				// messages authenticated by the backend, e.g. votes aggregated over the
+				// tree, which carry no signature of their own, are recorded decoded
+				if ev.Msg != nil {
+					c.record(recorder.Backlog, ev.Msg.Address, ev.Msg)
+					c.handleDecodedMsg(ev.Msg)
+				} else {
+					c.recorder.Record(recorder.Message, ev.Src, ev.Payload)
+					c.handleMsg(ev.Src, ev.Payload)
+				}
 
 			case backlogEvent:
+				c.record(recorder.Backlog, ev.msg.Address, ev.msg)
 				c.handleCheckedMsg(ev.msg)
+
+			case proposeEvent:
+				c.record(recorder.Propose, common.Address{}, ev.view)
+				c.handleProposeEvent(ev.view)
 			}
 
 		case _, ok := <-c.timeoutSub.Chan():
@@ -102,6 +152,7 @@ func (c *Core) handleEvents() {
 				logger.Error("Failed to receive timeout Event")
 				return
 			}
+			c.recorder.Record(recorder.Timeout, common.Address{}, nil)
 			c.handleTimeoutMsg()
 
 		case evt, ok := <-c.finalCommittedSub.Chan():
@@ -111,12 +162,42 @@ func (c *Core) handleEvents() {
 			}
 			switch ev := evt.Data.(type) {
 			case hs.FinalCommittedEvent:
+				c.record(recorder.FinalCommitted, common.Address{}, ev.Header)
 				c.handleFinalCommitted(ev.Header)
 			}
 		}
 	}
 }
 
+// handleProposeEvent sends the proposal scheduled at the view, unless the round
+// changed or the proposal was rescheduled in the meantime.
+func (c *Core) handleProposeEvent(view *hs.View) {
+	if c.proposeView == nil || c.proposeView != view {
+		return
+	}
+	c.proposeView = nil
+	c.sendPrepare()
+}
+
+// record records an event carrying an RLP encoded value, if recording is enabled
+func (c *Core) record(kind recorder.Kind, src common.Address, val interface{}) {
+	if c.recorder == nil {
+		return
+	}
+	data, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		c.logger.Warn("Failed to encode consensus record", "kind", kind, "err", err)
+		return
+	}
+	c.recorder.Record(kind, src, data)
+}
+
+// mark adds an event of the current view to the timeline
+func (c *Core) mark(kind timeline.Kind, code string) {
+	view := c.currentView()
+	c.timeline.Add(view.HeightU64(), view.RoundU64(), &timeline.Event{Kind: kind, Code: code})
+}
+
 // sendEvent sends events to mux
 func (c *Core) sendEvent(ev interface{}) {
 	c.backend.EventMux().Post(ev)
@@ -131,6 +212,14 @@ func (c *Core) handleMsg(val common.Address, payload []byte) error {
 		logger.Error("Failed to decode Message from payload", "err", err)
 		return hs.ErrFailedDecodeMessage
 	}
+	return c.handleDecodedMsg(msg)
+}
+
+// handleDecodedMsg handles a message whose signature was checked, by the backend or
+// by handleMsg
+func (c *Core) handleDecodedMsg(msg *hs.Message) error {
+	logger := c.logger.New()
+	val := msg.Address
 
 	// Only accept message if the src is consensus participant
 	index, src := c.valSet.GetByAddress(val)
@@ -138,6 +227,9 @@ func (c *Core) handleMsg(val common.Address, payload []byte) error {
 		logger.Error("Invalid address in Message", "msgCode", msg)
 		return hs.ErrInvalidSigner
 	}
+	if msg.View != nil {
+		c.timeline.Add(msg.View.HeightU64(), msg.View.RoundU64(), &timeline.Event{Kind: timeline.Received, Code: msg.Code.String(), Peer: &val})
+	}
 
 	// handle checked Message
 	return c.handleCheckedMsg(msg)
@@ -166,6 +258,8 @@ func (c *Core) handleCheckedMsg(msg *hs.Message) (err error) {
 		err = c.handleCommitVote(msg)
 	case hs.MsgTypeDecide:
 		err = c.handleDecide(msg)
+	case hs.MsgTypeSuspect:
+		err = c.handleSuspect(msg)
 	default:
 		err = hs.ErrInvalidMessage
 		c.logger.Error("msg type invalid", "unknown type", msg.Code)
@@ -180,6 +274,8 @@ func (c *Core) handleCheckedMsg(msg *hs.Message) (err error) {
 func (c *Core) handleTimeoutMsg() {
 	c.logger.Trace("handleTimeout", "state", c.currentState(), "view", c.currentView())
 	round := new(big.Int).Add(c.current.Round(), common.Big1)
+	atomic.AddUint64(&c.roundChanges, 1)
+	c.mark(timeline.Timeout, "")
 	c.startNewRound(round)
 }
 
@@ -204,6 +300,8 @@ func (c *Core) broadcast(code hs.MsgType, payload []byte) {
 		logger.Error("Failed to finalize Message", "msgCode", msg, "err", err)
 		return
 	}
+	c.recorder.Record(recorder.Sent, c.Address(), payload)
+	c.mark(timeline.Sent, code.String())
 
 	switch msg.Code {
 	case hs.MsgTypeNewView, hs.MsgTypePrepareVote, hs.MsgTypePreCommitVote, hs.MsgTypeCommitVote:
@@ -213,8 +311,8 @@ func (c *Core) broadcast(code hs.MsgType, payload []byte) {
 			logger.Error("Failed to unicast Message", "msgCode", msg, "err", err)
 		}
 
-	case hs.MsgTypePrepare, hs.MsgTypePreCommit, hs.MsgTypeCommit, hs.MsgTypeDecide:
-		// Leader broadcasts decision to replicas
+	case hs.MsgTypePrepare, hs.MsgTypePreCommit, hs.MsgTypeCommit, hs.MsgTypeDecide, hs.MsgTypeSuspect:
+		// Leader broadcasts decision to replicas, replicas their suspicions to each other
 
 		if err = c.backend.Broadcast(c.valSet, payload); err != nil {
 			logger.Error("Failed to broadcast Message", "msgCode", msg, "err", err)
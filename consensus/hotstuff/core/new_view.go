@@ -1,6 +1,9 @@
 package core
 
-import hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+import (
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
+)
 
 // sendNewView performs the NextView interrupt (see BHS specs)
 //  1. Replicas send latest PrepareQC to leader as the
@@ -59,7 +62,7 @@ func (c *Core) handleNewView(data *hs.Message) error {
 
 	logger.Trace("handleNewView", "msgCode", code, "src", src, "prepareQC", prepareQC.ProposedBlock)
 
-	if size := c.current.NewViewSize(); size >= c.valSet.Q() && c.currentState() < hs.StateHighQC {
+	if weight := c.current.NewViewWeight(); weight >= c.valSet.Q() && c.currentState() < hs.StateHighQC {
 		highQC, err := c.getHighQC()
 		if err != nil {
 			logger.Trace("Failed to get highQC", "msgCode", code, "err", err)
@@ -67,8 +70,9 @@ func (c *Core) handleNewView(data *hs.Message) error {
 		}
 		c.current.SetHighQC(highQC)
 		c.setCurrentState(hs.StateHighQC)
+		c.mark(timeline.Quorum, code.String())
 
-		logger.Trace("acceptHighQC", "msgCode", code, "prepareQC", prepareQC.ProposedBlock, "msgSize", size)
+		logger.Trace("acceptHighQC", "msgCode", code, "prepareQC", prepareQC.ProposedBlock, "msgWeight", weight)
 		c.sendPrepare()
 	}
 
@@ -2,6 +2,7 @@ package core
 
 import (
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 )
 
 // handlePrepareVote implements the PreCommit phase's leader portion (see BHS specs)
@@ -43,7 +44,7 @@ func (c *Core) handlePrepareVote(data *hs.Message) error {
 
 	logger.Trace("handlePrepareVote", "msgCode", code, "src", src, "vote", vote)
 
-	if size := c.current.PrepareVoteSize(); size >= c.valSet.Q() && c.currentState() == hs.StateHighQC {
+	if weight := c.current.PrepareVoteWeight(); weight >= c.valSet.Q() && c.currentState() == hs.StateHighQC {
 		prepareQC, err := c.messagesToQC(code)
 		if err != nil {
 			logger.Trace("Failed to assemble prepareQC", "msgCode", code, "err", err)
@@ -54,6 +55,7 @@ func (c *Core) handlePrepareVote(data *hs.Message) error {
 			return err
 		}
 		logger.Trace("acceptPrepareQC", "msgCode", code, "prepareQC", prepareQC.ProposedBlock)
+		c.mark(timeline.Quorum, code.String())
 
 		c.sendPreCommit(prepareQC)
 	}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/consensus"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -46,13 +47,14 @@ func (c *Core) sendPrepare() {
 		}
 	}
 
-	// consensus spent time always less than a block period, waiting for `delay` time to catch up the system time.
-	// todo(fuk): waiting in `startNewRound`
-	if block.Time() > uint64(time.Now().Unix()) {
-		delay := time.Unix(int64(block.Time()), 0).Sub(time.Now())
-		time.Sleep(delay)
+	// consensus spent time always less than a block period, schedule the proposal at the
+	// block timestamp instead of blocking the event loop until then.
+	if delay := time.Until(types.HotstuffHeaderTime(block.Header())); delay > 0 {
+		c.newProposeTimer(delay)
 		logger.Trace("delay to broadcast proposal", "msgCode", code, "time", delay.Milliseconds())
+		return
 	}
+	c.proposeView = nil
 
 	// assemble message
 	parent := highQC.ProposedBlock
@@ -132,6 +134,14 @@ func (c *Core) handlePrepare(data *hs.Message) error {
 		logger.Trace("Failed to check safeNode", "msgCode", code, "src", src, "err", err)
 		return hs.ErrSafeNode
 	}
+	if c.backend.SuspectCensorship(block, src) {
+		censorshipMeter.Mark(1)
+		logger.Warn("Leader suspected of censorship", "msgCode", code, "src", src, "block", block.Hash())
+		if c.config.CensorshipAction == hs.ViewChangeCensorship {
+			c.sendSuspect(block.Hash())
+			return hs.ErrCensoredProposal
+		}
+	}
 
 	logger.Trace("handlePrepare", "msgCode", code, "src", src, "node", node.Hash(), "block", block.Hash())
 
@@ -156,9 +166,9 @@ func (c *Core) handlePrepare(data *hs.Message) error {
 }
 
 func (c *Core) executeBlock(block *types.Block) error {
-	// proposer doesn't execute block again after miner.worker commitNewWork
-
-	if c.IsProposer() {
+	// proposer doesn't execute its own block again after miner.worker commitNewWork,
+	// a locked block proposed by another leader is executed like on any replica
+	if c.IsProposer() && c.isPendingRequest(block) {
 		c.current.executed = &consensus.ExecutedBlock{Block: block}
 		return nil
 	}
@@ -167,10 +177,16 @@ func (c *Core) executeBlock(block *types.Block) error {
 	if err != nil {
 		return err
 	}
+	c.mark(timeline.Executed, "")
 	c.current.executed = executed
 	return nil
 }
 
+func (c *Core) isPendingRequest(block *types.Block) bool {
+	request := c.current.PendingRequest()
+	return request != nil && request.Block != nil && request.Block.Hash() == block.Hash()
+}
+
 func (c *Core) safeNode(node *hs.ProposedBlock, highQC *hs.QuorumCert) error {
 	// check fields
 	if highQC == nil || highQC.View == nil {
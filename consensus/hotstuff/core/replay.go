@@ -0,0 +1,97 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/prque"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/recorder"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// NewReplay creates a core driven by Replay instead of the event mux of the backend.
+// Its timers still post timeouts and scheduled proposals to the mux, where nobody
+// listens, as the recorded ones are replayed in their place.
+func NewReplay(backend hs.Backend, config *hs.Config, signer hs.Signer, valSet hs.ValidatorSet) *Core {
+	c := &Core{
+		config:            config,
+		backend:           backend,
+		valSet:            valSet,
+		signer:            signer,
+		logger:            log.New("address", backend.Address()),
+		backlogs:          newBackLog(),
+		pendingRequests:   prque.New(nil),
+		pendingRequestsMu: new(sync.Mutex),
+		timeline:          timeline.New(backend.Address()),
+	}
+	c.validateFn = c.checkValidatorSignature
+	return c
+}
+
+// Replay handles a recorded event the way the event loop handled it. Sent messages
+// are left to the backend, backlogged messages are replayed from their own records.
+func (c *Core) Replay(rec *recorder.Record) error {
+	switch rec.Kind {
+	case recorder.Start:
+		c.stopTimer()
+		c.isRunning = true
+		c.current = nil
+		c.startNewRound(common.Big0)
+
+	case recorder.Request:
+		var block *types.Block
+		if err := rlp.DecodeBytes(rec.Data, &block); err != nil {
+			return err
+		}
+		return c.handleRequest(&hs.Request{Block: block})
+
+	case recorder.Message:
+		return c.handleMsg(rec.Src, rec.Data)
+
+	case recorder.Backlog:
+		msg := new(hs.Message)
+		if err := rlp.DecodeBytes(rec.Data, msg); err != nil {
+			return err
+		}
+		return c.handleCheckedMsg(msg)
+
+	case recorder.Propose:
+		var view *hs.View
+		if err := rlp.DecodeBytes(rec.Data, &view); err != nil {
+			return err
+		}
+		// the scheduled view is matched by identity, the replayed one by value
+		if c.proposeView != nil && c.proposeView.Cmp(view) == 0 {
+			c.handleProposeEvent(c.proposeView)
+		}
+
+	case recorder.Timeout:
+		c.handleTimeoutMsg()
+
+	case recorder.FinalCommitted:
+		var header *types.Header
+		if err := rlp.DecodeBytes(rec.Data, &header); err != nil {
+			return err
+		}
+		return c.handleFinalCommitted(header)
+
+	case recorder.Sent:
+
+	default:
+		return fmt.Errorf("unknown record kind %v", rec.Kind)
+	}
+	return nil
+}
+
+// ReplayState returns the view and state reached by the replay so far
+func (c *Core) ReplayState() (*hs.View, hs.State) {
+	if c.current == nil {
+		return nil, hs.StateAcceptRequest
+	}
+	return c.currentView(), c.currentState()
+}
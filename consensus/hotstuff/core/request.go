@@ -29,6 +29,13 @@ func (c *Core) handleRequest(request *hs.Request) error {
 			c.current.PendingRequest().Block.NumberU64() < c.current.HeightU64() {
 			c.current.SetPendingRequest(request)
 			c.sendPrepare()
+		} else if c.proposeView != nil {
+			// the proposal waits for its block timestamp, a newer block of the miner
+			// replaces the scheduled one, and is scheduled at its own timestamp, which
+			// is earlier than the one of a held back empty block.
+			c.current.SetPendingRequest(request)
+			logger.Trace("Replace scheduled request", "hash", request.Block.Hash())
+			c.sendPrepare()
 		} else {
 			logger.Trace("PendingRequest exist")
 		}
@@ -3,6 +3,8 @@ package core
 import (
 	"math"
 	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // we use timeout in every view to ensure consensus liveness.  and the view timeout
@@ -22,6 +24,14 @@ func (c *Core) newRoundChangeTimer() {
 	if round > 0 {
 		timeout += time.Duration(math.Pow(2, float64(round))) * time.Second
 	}
+	// replicas wait for an empty block as long as the leader may hold it back
+	if c.config.EmptyPeriod() > c.config.Period() {
+		if parent, _ := c.backend.LastProposal(); parent != nil {
+			if hold := time.Until(types.HotstuffHeaderTime(parent.Header()).Add(c.config.EmptyPeriod())); hold > 0 {
+				timeout += hold
+			}
+		}
+	}
 	c.roundChangeTimer = time.AfterFunc(timeout, func() {
 		c.sendEvent(timeoutEvent{})
 	})
@@ -31,4 +41,24 @@ func (c *Core) stopTimer() {
 	if c.roundChangeTimer != nil {
 		c.roundChangeTimer.Stop()
 	}
+	c.stopProposeTimer()
+}
+
+// newProposeTimer schedules the proposal of the current view at the block timestamp,
+// the leader keeps handling messages and requests in the meantime.
+func (c *Core) newProposeTimer(delay time.Duration) {
+	c.stopProposeTimer()
+
+	view := c.currentView()
+	c.proposeView = view
+	c.proposeTimer = time.AfterFunc(delay, func() {
+		c.sendEvent(proposeEvent{view: view})
+	})
+}
+
+func (c *Core) stopProposeTimer() {
+	if c.proposeTimer != nil {
+		c.proposeTimer.Stop()
+	}
+	c.proposeView = nil
 }
@@ -3,6 +3,9 @@ package core
 import hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 
 type timeoutEvent struct{}
+type proposeEvent struct {
+	view *hs.View
+}
 type backlogEvent struct {
 	src hs.Validator
 	msg *hs.Message
@@ -47,8 +47,12 @@ var (
 
 	ErrSafeNode = errors.New("safeNode checking failed")
 
+	ErrCensoredProposal = errors.New("proposal suspected of censorship")
+
 	ErrAddNewViews = errors.New("add new view error")
 
+	ErrAddSuspect = errors.New("add suspect error")
+
 	ErrAddPrepareVote = errors.New("add prepare vote error")
 
 	ErrAddPreCommitVote = errors.New("add pre commit vote error")
@@ -105,4 +109,6 @@ var (
 	ErrMismatchTxhashes = errors.New("mismatch transactions hashes")
 	// ErrDecodeFailed is returned if the message can't be decode
 	ErrDecodeFailed = errors.New("decode p2p message failed")
+	// ErrInvalidValidators is returned if the validators recorded in a header are not the set governing it.
+	ErrInvalidValidators = errors.New("invalid validator set")
 )
@@ -16,6 +16,7 @@ type RequestEvent struct {
 type MessageEvent struct {
 	Src     common.Address
 	Payload []byte
+	Msg     *Message // Payload decoded with its signature checked by the backend, nil if the core has to
 }
 
 // FinalCommittedEvent is posted when a proposal is committed
@@ -46,7 +46,7 @@ func (c *Core) handlePreCommitVote(data *hs.Message) error {
 
 	logger.Trace("handlePreCommitVote", "msg", code, "src", src, "hash", vote)
 
-	if size := c.current.PreCommitVoteSize(); size >= c.valSet.Q() && c.currentState() < hs.StatePreCommitted {
+	if weight := c.current.PreCommitVoteWeight(); weight >= c.valSet.Q() && c.currentState() < hs.StatePreCommitted {
 		lockQC, err := c.messagesToQC(code)
 		if err != nil {
 			logger.Trace("Failed to assemble lockQC", "msg", code, "err", err)
@@ -56,7 +56,7 @@ func (c *Core) handlePreCommitVote(data *hs.Message) error {
 			logger.Trace("Failed to accept lockQC", "msg", code, "err", err)
 			return err
 		}
-		logger.Trace("acceptLockQC", "msg", code, "msgSize", size)
+		logger.Trace("acceptLockQC", "msg", code, "msgWeight", weight)
 
 		c.sendCommit(lockQC)
 	}
@@ -12,8 +12,12 @@ import (
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
+// censorshipMeter counts the proposals whose leader was suspected of censorship
+var censorshipMeter = metrics.NewRegisteredMeter("consensus/hotstuff/faulty/censorship", nil)
+
 type Core struct {
 	db     ethdb.Database
 	config *hs.Config
@@ -32,10 +36,14 @@ type Core struct {
 	finalCommittedSub *event.TypeMuxSubscription
 
 	roundChangeTimer *time.Timer
+	proposeTimer     *time.Timer
+	proposeView      *hs.View // View of the proposal waiting for its block timestamp
 
 	pendingRequests   *prque.Prque
 	pendingRequestsMu *sync.Mutex
 
+	roundChanges uint64 // Number of timed out rounds, accessed atomically
+
 	validateFn func(common.Hash, []byte) (common.Address, error)
 	isRunning  bool
 }
@@ -105,8 +113,25 @@ func (c *Core) startNewRound(round *big.Int) {
 		newView.Round = new(big.Int).Set(round)
 	}
 
+	// follow validator set changes once per height, before the proposer is chosen
+	if !changeView {
+		valSet, err := c.backend.UpdateValidators(lastProposal.Header())
+		if err != nil {
+			logger.Error("Failed to update validator set", "number", lastProposal.NumberU64(), "err", err)
+			return
+		}
+		if valSet != nil {
+			c.valSet = valSet
+		}
+	}
+
+	// score leaders on the chain history once per height
+	if !changeView && c.valSet.Policy() == hs.Reputation {
+		c.valSet.UpdateReputation(c.backend.CommitHistory(lastProposal.NumberU64(), c.config.ReputationWindow))
+	}
+
 	// calculate new proposal and init round state
-	c.valSet.CalcProposer(lastProposer, newView.Round.Uint64())
+	c.valSet.CalcProposer(lastProposer, newView.Height.Uint64(), newView.Round.Uint64())
 
 	// update smr and try to unlock at the round0
 	if err := c.updateRoundState(lastProposal, newView); err != nil {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/consensus"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/core/types"
 )
@@ -59,7 +58,7 @@ func (c *Core) handleCommitVote(data *hs.Message) error {
 	logger.Trace("handleCommitVote", "msg", code, "src", src, "hash", vote)
 
 	// assemble committed signatures to reorg the locked block, and create `commitQC` at the same time.
-	if size := c.current.CommitVoteSize(); size >= c.valSet.Q() && c.currentState() == hs.StatePreCommitted {
+	if weight := c.current.CommitVoteWeight(); weight >= c.valSet.Q() && c.currentState() == hs.StatePreCommitted {
 		commitQC, err := c.messagesToQC(code)
 		if err != nil {
 			logger.Trace("Failed to assemble commitQC", "msg", code, "err", err)
@@ -84,7 +83,7 @@ func (c *Core) handleCommitVote(data *hs.Message) error {
 			logger.Trace("Failed to accept commitQC", "msg", code, "err", err)
 			return err
 		}
-		logger.Trace("acceptCommit", "msg", code, "msgSize", size)
+		logger.Trace("acceptCommit", "msg", code, "msgWeight", weight)
 
 		c.sendDecide(sealedBlock.Hash(), commitQC)
 	}
@@ -221,16 +220,10 @@ func (c *Core) commit(sealedBlock *types.Block) error {
 		return fmt.Errorf("expect locked block %v, got %v", lockedBlock.Hash(), sealedBlock.Hash())
 	}
 
+	// the block is normally executed in Prepare already
 	if c.current.executed == nil || c.current.executed.Block == nil || c.current.executed.Block.Hash() != sealedBlock.Hash() {
-		if c.IsProposer() {
-			c.current.executed = &consensus.ExecutedBlock{Block: sealedBlock}
-		} else {
-			executed, err := c.backend.ExecuteBlock(sealedBlock)
-			if err != nil {
-				return fmt.Errorf("failed to execute block %v, err: %v", sealedBlock.Hash(), err)
-			}
-			executed.Block = sealedBlock
-			c.current.executed = executed
+		if err := c.executeBlock(sealedBlock); err != nil {
+			return fmt.Errorf("failed to execute block %v, err: %v", sealedBlock.Hash(), err)
 		}
 	}
 
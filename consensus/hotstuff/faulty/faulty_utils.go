@@ -12,24 +12,26 @@ func (c *Core) isFaultTriggered(fault hs.FaultyMode, h uint64, r uint64) bool {
 		cr == r
 }
 
+// pickNValidators picks validators other than the local node holding a voting power
+// of at most N, skipping the validators which would exceed it
 func (c *Core) pickNValidators(valSet hs.ValidatorSet, N int) []common.Address {
-	vals := make([]common.Address, N)
-	i := 0
-	for _, addr := range valSet.AddressList() {
-		if addr == c.Address() {
+	var (
+		vals   []common.Address
+		weight int
+	)
+	for i := 0; i < valSet.Size() && weight < N; i++ {
+		val := valSet.GetByIndex(uint64(i))
+		if val.Address() == c.Address() || weight+val.Weight() > N {
 			continue
 		}
-
-		if i == N {
-			break
-		}
-
-		vals[i] = addr
-		i += 1
+		vals = append(vals, val.Address())
+		weight += val.Weight()
 	}
 	return vals
 }
 
+// splitValSet splits the validators into a set holding a voting power of N, which
+// excludes the local node, and the set of the remaining validators
 func (c *Core) splitValSet(valSet hs.ValidatorSet, N int) (hs.ValidatorSet, hs.ValidatorSet) {
 	vsA := valSet.Copy()
 	vsB := valSet.Copy()
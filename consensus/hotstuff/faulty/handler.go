@@ -2,9 +2,11 @@ package faulty
 
 import (
 	"math/big"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/common"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
 )
 
 // Start implements core.Engine.Start
@@ -56,6 +58,15 @@ func (c *Core) CurrentSequence() (uint64, uint64) {
 	return view.HeightU64(), view.RoundU64()
 }
 
+func (c *Core) RoundChanges() uint64 {
+	return atomic.LoadUint64(&c.roundChanges)
+}
+
+// Timeline is not kept by faulty nodes
+func (c *Core) Timeline(height uint64) []*timeline.View {
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 
 // Subscribe both internal and external events
@@ -66,6 +77,7 @@ func (c *Core) subscribeEvents() {
 		// internal events
 		hs.MessageEvent{},
 		backlogEvent{},
+		proposeEvent{},
 	)
 	c.timeoutSub = c.backend.EventMux().Subscribe(
 		timeoutEvent{},
@@ -95,6 +107,9 @@ func (c *Core) handleEvents() {
 
 			case backlogEvent:
 				c.handleCheckedMsg(ev.msg)
+
+			case proposeEvent:
+				c.handleProposeEvent(ev.view)
 			}
 
 		case _, ok := <-c.timeoutSub.Chan():
@@ -117,6 +132,16 @@ func (c *Core) handleEvents() {
 	}
 }
 
+// handleProposeEvent sends the proposal scheduled at the view, unless the round
+// changed or the proposal was rescheduled in the meantime.
+func (c *Core) handleProposeEvent(view *hs.View) {
+	if c.proposeView == nil || c.proposeView != view {
+		return
+	}
+	c.proposeView = nil
+	c.sendPrepare()
+}
+
 // sendEvent sends events to mux
 func (c *Core) sendEvent(ev interface{}) {
 	c.backend.EventMux().Post(ev)
@@ -166,6 +191,8 @@ func (c *Core) handleCheckedMsg(msg *hs.Message) (err error) {
 		err = c.handleCommitVote(msg)
 	case hs.MsgTypeDecide:
 		err = c.handleDecide(msg)
+	case hs.MsgTypeSuspect:
+		err = c.handleSuspect(msg)
 	default:
 		err = hs.ErrInvalidMessage
 		c.logger.Error("msg type invalid", "unknown type", msg.Code)
@@ -180,6 +207,7 @@ func (c *Core) handleCheckedMsg(msg *hs.Message) (err error) {
 func (c *Core) handleTimeoutMsg() {
 	c.logger.Trace("handleTimeout", "state", c.currentState(), "view", c.currentView())
 	round := new(big.Int).Add(c.current.Round(), common.Big1)
+	atomic.AddUint64(&c.roundChanges, 1)
 	c.startNewRound(round)
 }
 
@@ -213,8 +241,8 @@ func (c *Core) broadcast(code hs.MsgType, payload []byte) {
 			logger.Error("Failed to unicast Message", "msg", msg, "err", err)
 		}
 
-	case hs.MsgTypePrepare, hs.MsgTypePreCommit, hs.MsgTypeCommit, hs.MsgTypeDecide:
-		// Leader broadcasts decision to replicas
+	case hs.MsgTypePrepare, hs.MsgTypePreCommit, hs.MsgTypeCommit, hs.MsgTypeDecide, hs.MsgTypeSuspect:
+		// Leader broadcasts decision to replicas, replicas their suspicions to each other
 
 		if err = c.backend.Broadcast(c.valSet, payload); err != nil {
 			logger.Error("Failed to broadcast Message", "msg", msg, "err", err)
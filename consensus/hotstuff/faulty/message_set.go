@@ -66,6 +66,20 @@ func (s *MessageSet) Size() int {
 	return len(s.msgs)
 }
 
+// Weight returns the voting power of the validators which sent a message
+func (s *MessageSet) Weight() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	weight := 0
+	for addr := range s.msgs {
+		if _, v := s.vs.GetByAddress(addr); v != nil {
+			weight += v.Weight()
+		}
+	}
+	return weight
+}
+
 func (s *MessageSet) Get(addr common.Address) *hs.Message {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -59,7 +59,7 @@ func (c *Core) handleNewView(data *hs.Message) error {
 
 	logger.Trace("handleNewView", "msg", code, "src", src, "prepareQC", prepareQC.ProposedBlock)
 
-	if size := c.current.NewViewSize(); size >= c.valSet.Q() && c.currentState() < hs.StateHighQC {
+	if weight := c.current.NewViewWeight(); weight >= c.valSet.Q() && c.currentState() < hs.StateHighQC {
 		highQC, err := c.getHighQC()
 		if err != nil {
 			logger.Trace("Failed to get highQC", "msg", code, "err", err)
@@ -68,7 +68,7 @@ func (c *Core) handleNewView(data *hs.Message) error {
 		c.current.SetHighQC(highQC)
 		c.setCurrentState(hs.StateHighQC)
 
-		logger.Trace("acceptHighQC", "msg", code, "prepareQC", prepareQC.ProposedBlock, "msgSize", size)
+		logger.Trace("acceptHighQC", "msg", code, "prepareQC", prepareQC.ProposedBlock, "msgWeight", weight)
 		c.sendPrepare()
 	}
 
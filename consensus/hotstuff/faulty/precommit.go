@@ -48,7 +48,7 @@ func (c *Core) handlePrepareVote(data *hs.Message) error {
 
 	logger.Trace("handlePrepareVote", "msg", code, "src", src, "vote", vote)
 
-	if size := c.current.PrepareVoteSize(); size >= c.valSet.Q() && c.currentState() == hs.StateHighQC {
+	if weight := c.current.PrepareVoteWeight(); weight >= c.valSet.Q() && c.currentState() == hs.StateHighQC {
 		prepareQC, err := c.messagesToQC(code)
 		if err != nil {
 			logger.Trace("Failed to assemble prepareQC", "msg", code, "err", err)
@@ -43,13 +43,14 @@ func (c *Core) sendPrepare() {
 		}
 	}
 
-	// consensus spent time always less than a block period, waiting for `delay` time to catch up the system time.
-	// todo(fuk): waiting in `startNewRound`
-	if block.Time() > uint64(time.Now().Unix()) {
-		delay := time.Unix(int64(block.Time()), 0).Sub(time.Now())
-		time.Sleep(delay)
+	// consensus spent time always less than a block period, schedule the proposal at the
+	// block timestamp instead of blocking the event loop until then.
+	if delay := time.Until(types.HotstuffHeaderTime(block.Header())); delay > 0 {
+		c.newProposeTimer(delay)
 		logger.Trace("delay to broadcast proposal", "msg", code, "time", delay.Milliseconds())
+		return
 	}
+	c.proposeView = nil
 
 	// assemble message as formula: MSG(view, node, prepareQC)
 	parent := highQC.ProposedBlock
@@ -135,6 +136,14 @@ func (c *Core) handlePrepare(data *hs.Message) error {
 		logger.Trace("Failed to check safeNode", "msg", code, "src", src, "err", err)
 		return hs.ErrSafeNode
 	}
+	if c.backend.SuspectCensorship(block, src) {
+		censorshipMeter.Mark(1)
+		logger.Warn("Leader suspected of censorship", "msg", code, "src", src, "block", block.Hash())
+		if c.config.CensorshipAction == hs.ViewChangeCensorship {
+			c.sendSuspect(block.Hash())
+			return hs.ErrCensoredProposal
+		}
+	}
 
 	logger.Trace("handlePrepare", "msg", code, "src", src, "node", node.Hash(), "block", block.Hash())
 
@@ -158,9 +167,9 @@ func (c *Core) handlePrepare(data *hs.Message) error {
 	return nil
 }
 
-// proposer do not need execute block again after miner.worker commitNewWork.
+// proposer do not need execute its own block again after miner.worker commitNewWork.
 func (c *Core) executeBlock(block *types.Block) error {
-	if c.IsProposer() {
+	if c.IsProposer() && c.isPendingRequest(block) {
 		c.current.executed = &consensus.ExecutedBlock{Block: block}
 		return nil
 	}
@@ -173,6 +182,11 @@ func (c *Core) executeBlock(block *types.Block) error {
 	return nil
 }
 
+func (c *Core) isPendingRequest(block *types.Block) bool {
+	request := c.current.PendingRequest()
+	return request != nil && request.Block != nil && request.Block.Hash() == block.Hash()
+}
+
 func (c *Core) safeNode(node *hs.ProposedBlock, highQC *hs.QuorumCert) error {
 	// Data checks
 	if highQC == nil || highQC.View == nil {
@@ -42,12 +42,14 @@ type roundState struct {
 	lockedBlock      *types.Block // validator's prepare proposal
 	executed         *consensus.ExecutedBlock
 	proposalLocked   bool
+	suspected        bool // Whether the local node suspected the leader of the round
 
 	// o(4n)
 	newViews       *MessageSet // data set for newView message
 	prepareVotes   *MessageSet // data set for prepareVote message
 	preCommitVotes *MessageSet // data set for preCommitVote message
 	commitVotes    *MessageSet // data set for commitVote message
+	suspects       *MessageSet // data set for suspect message
 
 	highQC      *hs.QuorumCert // leader highQC
 	prepareQC   *hs.QuorumCert // prepareQC for repo and leader
@@ -70,6 +72,7 @@ func newRoundState(db ethdb.Database, logger log.Logger, validatorSet hs.Validat
 		prepareVotes:     NewMessageSet(validatorSet),
 		preCommitVotes:   NewMessageSet(validatorSet),
 		commitVotes:      NewMessageSet(validatorSet),
+		suspects:         NewMessageSet(validatorSet),
 	}
 	return rs
 }
@@ -84,6 +87,8 @@ func (s *roundState) update(vs hs.ValidatorSet, lastChainedBlock *types.Block, v
 	s.prepareVotes = NewMessageSet(vs)
 	s.preCommitVotes = NewMessageSet(vs)
 	s.commitVotes = NewMessageSet(vs)
+	s.suspects = NewMessageSet(vs)
+	s.suspected = false
 
 	return s
 }
@@ -270,6 +275,10 @@ func (s *roundState) NewViewSize() int {
 	return s.newViews.Size()
 }
 
+func (s *roundState) NewViewWeight() int {
+	return s.newViews.Weight()
+}
+
 func (s *roundState) NewViews() []*hs.Message {
 	return s.newViews.Values()
 }
@@ -286,6 +295,10 @@ func (s *roundState) PrepareVoteSize() int {
 	return s.prepareVotes.Size()
 }
 
+func (s *roundState) PrepareVoteWeight() int {
+	return s.prepareVotes.Weight()
+}
+
 func (s *roundState) AddPreCommitVote(msg *hs.Message) error {
 	return s.preCommitVotes.Add(msg)
 }
@@ -298,6 +311,10 @@ func (s *roundState) PreCommitVoteSize() int {
 	return s.preCommitVotes.Size()
 }
 
+func (s *roundState) PreCommitVoteWeight() int {
+	return s.preCommitVotes.Weight()
+}
+
 func (s *roundState) AddCommitVote(msg *hs.Message) error {
 	return s.commitVotes.Add(msg)
 }
@@ -310,6 +327,31 @@ func (s *roundState) CommitVoteSize() int {
 	return s.commitVotes.Size()
 }
 
+func (s *roundState) CommitVoteWeight() int {
+	return s.commitVotes.Weight()
+}
+
+// -----------------------------------------------------------------------
+//
+// replicas collect censorship suspicions
+//
+// -----------------------------------------------------------------------
+func (s *roundState) AddSuspect(msg *hs.Message) error {
+	return s.suspects.Add(msg)
+}
+
+func (s *roundState) SuspectWeight() int {
+	return s.suspects.Weight()
+}
+
+func (s *roundState) Suspected() bool {
+	return s.suspected
+}
+
+func (s *roundState) SetSuspected() {
+	s.suspected = true
+}
+
 // -----------------------------------------------------------------------
 //
 // store round state as snapshot
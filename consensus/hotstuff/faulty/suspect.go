@@ -0,0 +1,59 @@
+package faulty
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+)
+
+// sendSuspect broadcasts the censorship suspicion of the local node against the
+// leader of the current view, once per view. The proposal is not voted on.
+func (c *Core) sendSuspect(block common.Hash) {
+	logger := c.newLogger()
+
+	if c.current.Suspected() {
+		return
+	}
+	code := hs.MsgTypeSuspect
+	payload, err := hs.Encode(block)
+	if err != nil {
+		logger.Trace("Failed to encode", "msgCode", code, "err", err)
+		return
+	}
+	c.current.SetSuspected()
+	c.broadcast(code, payload)
+}
+
+// handleSuspect collects the censorship suspicions against the leader of the current
+// view. Pools differ between nodes, so a single replica does not change the view on
+// its own: the round only ends early once the suspects hold more than the faulty
+// voting power, i.e. once at least one honest validator suspects the leader.
+// Otherwise the view goes on, or times out as usual.
+func (c *Core) handleSuspect(data *hs.Message) error {
+	var (
+		logger = c.newLogger()
+		code   = data.Code
+		src    = data.Address
+		block  common.Hash
+	)
+
+	if err := data.Decode(&block); err != nil {
+		logger.Trace("Failed to decode", "msgCode", code, "src", src, "err", err)
+		return hs.ErrFailedDecodeMessage
+	}
+	if err := c.checkView(data.View); err != nil {
+		logger.Trace("Failed to check view", "msgCode", code, "src", src, "err", err)
+		return err
+	}
+	if err := c.current.AddSuspect(data); err != nil {
+		logger.Trace("Failed to add suspect", "msgCode", code, "src", src, "err", err)
+		return hs.ErrAddSuspect
+	}
+
+	logger.Trace("handleSuspect", "msgCode", code, "src", src, "block", block)
+
+	if weight := c.current.SuspectWeight(); weight > c.valSet.F() {
+		logger.Warn("Leader suspected of censorship by validators, changing view", "weight", weight, "block", block)
+		c.handleTimeoutMsg()
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package mock
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type BenchProtocol string
+
+const (
+	BenchHotStuff BenchProtocol = "hotstuff"
+	BenchQBFT     BenchProtocol = "qbft"
+)
+
+// BenchConfig describes a consensus benchmark run. The same config yields the same
+// workload for every protocol so that results can be compared side by side.
+type BenchConfig struct {
+	Validators     int           // Number of in-process validators
+	Accounts       int           // Number of funded accounts sending transactions
+	TxRate         int           // Transactions submitted per second, 0 for empty blocks
+	Duration       time.Duration // Measurement window, after warm up
+	Warmup         time.Duration // Time given to the network to produce its first blocks
+	BlockPeriod    uint64        // Block period in seconds
+	RequestTimeout uint64        // Round timeout in milliseconds
+	Topology       hs.Topology   // HotStuff message overlay
+	TreeFanout     uint64        // Children per node in the Tree topology, sqrt(n) if 0
+	Compact        bool          // Send transaction hashes instead of full blocks in HotStuff Prepare messages
+}
+
+var DefaultBenchConfig = BenchConfig{
+	Validators:     4,
+	Accounts:       8,
+	TxRate:         200,
+	Duration:       30 * time.Second,
+	Warmup:         5 * time.Second,
+	BlockPeriod:    1,
+	RequestTimeout: 4000,
+	Topology:       hs.Star,
+}
+
+// BenchResult holds the measurements of a single benchmark run, taken at the
+// first validator acting as an observer.
+type BenchResult struct {
+	Protocol   BenchProtocol
+	Validators int
+	Elapsed    time.Duration
+
+	Blocks    uint64
+	Txs       uint64
+	Submitted uint64
+
+	LatencyP50 time.Duration // Submission to commit latency percentiles
+	LatencyP90 time.Duration
+	LatencyP99 time.Duration
+
+	Msgs  uint64 // Consensus messages sent over the network
+	Bytes uint64 // Consensus payload bytes sent over the network
+}
+
+func (r *BenchResult) TPS() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Txs) / r.Elapsed.Seconds()
+}
+
+func (r *BenchResult) MsgsPerBlock() float64 {
+	if r.Blocks == 0 {
+		return 0
+	}
+	return float64(r.Msgs) / float64(r.Blocks)
+}
+
+func (r *BenchResult) BytesPerBlock() float64 {
+	if r.Blocks == 0 {
+		return 0
+	}
+	return float64(r.Bytes) / float64(r.Blocks)
+}
+
+func (r *BenchResult) String() string {
+	return fmt.Sprintf("%-8s n=%-3d blocks=%-4d txs=%-6d tps=%-8.1f p50=%-8v p90=%-8v p99=%-8v msgs/block=%-8.1f bytes/block=%.0f",
+		r.Protocol, r.Validators, r.Blocks, r.Txs, r.TPS(),
+		r.LatencyP50.Round(time.Millisecond), r.LatencyP90.Round(time.Millisecond), r.LatencyP99.Round(time.Millisecond),
+		r.MsgsPerBlock(), r.BytesPerBlock())
+}
+
+// benchNetwork abstracts the in-process networks driven by RunBench
+type benchNetwork interface {
+	Start()
+	Stop()
+	Observer() *core.BlockChain
+	Stats() *netStats
+}
+
+// RunBench starts cfg.Validators in-process validators running the given protocol,
+// feeds them transactions at cfg.TxRate and measures throughput, commit latency and
+// consensus traffic at the first validator.
+func RunBench(protocol BenchProtocol, cfg BenchConfig) (*BenchResult, error) {
+	if cfg.Validators < 1 {
+		return nil, fmt.Errorf("invalid validator count %d", cfg.Validators)
+	}
+	if cfg.Accounts < 1 {
+		cfg.Accounts = 1
+	}
+
+	var (
+		keys, alloc = newWorkloadAccounts(cfg.Accounts)
+		pool        = newTxPool()
+		network     benchNetwork
+	)
+	switch protocol {
+	case BenchHotStuff:
+		network = makeHotStuffBenchSystem(cfg, alloc, pool)
+	case BenchQBFT:
+		network = makeQBFTBenchSystem(cfg, alloc, pool)
+	default:
+		return nil, fmt.Errorf("unknown protocol %q", protocol)
+	}
+
+	observer := network.Observer()
+	feeder := newTxFeeder(observer.Config(), keys, cfg.TxRate, pool)
+	headCh := make(chan core.ChainHeadEvent, 64)
+	headSub := observer.SubscribeChainHeadEvent(headCh)
+	defer headSub.Unsubscribe()
+
+	network.Start()
+	defer network.Stop()
+
+	time.Sleep(cfg.Warmup)
+
+	var (
+		result    = &BenchResult{Protocol: protocol, Validators: cfg.Validators}
+		deadline  = time.NewTimer(cfg.Duration)
+		latencies []time.Duration
+	)
+	defer deadline.Stop()
+
+	// drain heads produced during warm up
+	for drained := false; !drained; {
+		select {
+		case <-headCh:
+		default:
+			drained = true
+		}
+	}
+
+	startMsgs, startBytes := network.Stats().Load()
+	start := time.Now()
+	feeder.Start()
+
+	for running := true; running; {
+		select {
+		case ev := <-headCh:
+			result.Blocks++
+			result.Txs += uint64(len(ev.Block.Transactions()))
+			latencies = append(latencies, feeder.Confirm(ev.Block, time.Now())...)
+			pool.Remove(ev.Block.Transactions())
+
+		case <-deadline.C:
+			running = false
+		}
+	}
+	feeder.Stop()
+
+	result.Elapsed = time.Since(start)
+	endMsgs, endBytes := network.Stats().Load()
+	result.Msgs, result.Bytes = endMsgs-startMsgs, endBytes-startBytes
+	result.Submitted = feeder.Submitted()
+	result.LatencyP50 = percentile(latencies, 0.50)
+	result.LatencyP90 = percentile(latencies, 0.90)
+	result.LatencyP99 = percentile(latencies, 0.99)
+
+	log.Info("Benchmark finished", "result", result)
+	return result, nil
+}
+
+// percentile returns the p-th percentile of durations, sorting them in place
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	idx := int(float64(len(durations)-1) * p)
+	return durations[idx]
+}
+
+// ----------------------------------------------------------------------------
+
+type hotstuffBenchSystem struct {
+	*System
+	stats *netStats
+}
+
+func makeHotStuffBenchSystem(cfg BenchConfig, alloc core.GenesisAlloc, pool *txPool) *hotstuffBenchSystem {
+	config := &hs.Config{
+		RequestTimeout:  cfg.RequestTimeout,
+		BlockPeriod:     cfg.BlockPeriod,
+		LeaderPolicy:    hs.RoundRobin,
+		FaultyMode:      hs.Disabled,
+		Topology:        cfg.Topology,
+		TreeFanout:      cfg.TreeFanout,
+		CompactProposal: cfg.Compact,
+	}
+
+	pks, blsinfos, addrs := newAccountLists(cfg.Validators)
+	stats := new(netStats)
+	nodes := make([]*Geth, cfg.Validators)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, config, alloc)
+		nodes[i].stats = stats
+		nodes[i].miner.txpool = pool
+		nodes[i].engine.(consensus.TxPoolHandler).SetTxPool(pool)
+	}
+	return &hotstuffBenchSystem{
+		System: &System{nodes: nodes, exit: make(chan struct{})},
+		stats:  stats,
+	}
+}
+
+func (s *hotstuffBenchSystem) Stop() {
+	s.System.Stop()
+	// System stops nodes asynchronously, give them the time to shut down
+	time.Sleep(time.Second)
+}
+
+func (s *hotstuffBenchSystem) Observer() *core.BlockChain { return s.nodes[0].chain }
+
+func (s *hotstuffBenchSystem) Stats() *netStats { return s.stats }
+
+type qbftBenchSystem struct {
+	nodes []*qbftNode
+	stats *netStats
+}
+
+func makeQBFTBenchSystem(cfg BenchConfig, alloc core.GenesisAlloc, pool *txPool) *qbftBenchSystem {
+	pks, _, addrs := newAccountLists(cfg.Validators)
+	stats := new(netStats)
+	nodes := make([]*qbftNode, cfg.Validators)
+	for i := range nodes {
+		nodes[i] = makeQBFTNode(pks[i], makeQBFTGenesis(addrs, alloc), cfg.BlockPeriod, cfg.RequestTimeout)
+		nodes[i].stats = stats
+		nodes[i].txpool = pool
+	}
+	return &qbftBenchSystem{nodes: nodes, stats: stats}
+}
+
+func (s *qbftBenchSystem) Start() {
+	for i := 0; i < len(s.nodes); i++ {
+		for j := i + 1; j < len(s.nodes); j++ {
+			s.nodes[i].broadcaster.Connect(s.nodes[j].broadcaster)
+		}
+	}
+	for _, node := range s.nodes {
+		node.Start()
+	}
+}
+
+func (s *qbftBenchSystem) Stop() {
+	for _, node := range s.nodes {
+		node.Stop()
+	}
+}
+
+func (s *qbftBenchSystem) Observer() *core.BlockChain { return s.nodes[0].chain }
+
+func (s *qbftBenchSystem) Stats() *netStats { return s.stats }
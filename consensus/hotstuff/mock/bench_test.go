@@ -0,0 +1,70 @@
+package mock
+
+import (
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	benchProtocol  = flag.String("hsbench.protocol", "", "Benchmark a single protocol (hotstuff or qbft), both if empty")
+	benchNodes     = flag.Int("hsbench.nodes", DefaultBenchConfig.Validators, "Number of in-process validators")
+	benchAccounts  = flag.Int("hsbench.accounts", DefaultBenchConfig.Accounts, "Number of accounts sending transactions")
+	benchRate      = flag.Int("hsbench.rate", DefaultBenchConfig.TxRate, "Transactions submitted per second")
+	benchDuration  = flag.Duration("hsbench.duration", DefaultBenchConfig.Duration, "Measurement window")
+	benchWarmup    = flag.Duration("hsbench.warmup", DefaultBenchConfig.Warmup, "Warm up time before measuring")
+	benchPeriod    = flag.Uint64("hsbench.period", DefaultBenchConfig.BlockPeriod, "Block period in seconds")
+	benchTimeout   = flag.Uint64("hsbench.timeout", DefaultBenchConfig.RequestTimeout, "Round timeout in milliseconds")
+	benchTopology  = flag.String("hsbench.topology", string(DefaultBenchConfig.Topology), "HotStuff message overlay (Star or Tree)")
+	benchFanout    = flag.Uint64("hsbench.fanout", DefaultBenchConfig.TreeFanout, "Children per node in the Tree topology, sqrt(n) if 0")
+	benchCompact   = flag.Bool("hsbench.compact", DefaultBenchConfig.Compact, "Send transaction hashes instead of full blocks in HotStuff Prepare messages")
+	benchVerbosity = flag.Int("hsbench.verbosity", int(log.LvlCrit), "Log verbosity during benchmarks")
+)
+
+// BenchmarkConsensus measures throughput, commit latency and consensus traffic of
+// HotStuff and QBFT on the same workload. Each protocol is run once per benchmark,
+// regardless of b.N, e.g.
+//
+//	go test -run XXX -bench Consensus -benchtime 1x -hsbench.nodes 7 -hsbench.rate 500
+func BenchmarkConsensus(b *testing.B) {
+	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
+	glogger.Verbosity(log.Lvl(*benchVerbosity))
+	log.Root().SetHandler(glogger)
+
+	cfg := BenchConfig{
+		Validators:     *benchNodes,
+		Accounts:       *benchAccounts,
+		TxRate:         *benchRate,
+		Duration:       *benchDuration,
+		Warmup:         *benchWarmup,
+		BlockPeriod:    *benchPeriod,
+		RequestTimeout: *benchTimeout,
+		Topology:       hs.Topology(*benchTopology),
+		TreeFanout:     *benchFanout,
+		Compact:        *benchCompact,
+	}
+	for _, protocol := range []BenchProtocol{BenchHotStuff, BenchQBFT} {
+		if *benchProtocol != "" && BenchProtocol(*benchProtocol) != protocol {
+			continue
+		}
+		b.Run(string(protocol), func(b *testing.B) {
+			result, err := RunBench(protocol, cfg)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.Log(result)
+
+			b.ReportMetric(result.TPS(), "tps")
+			b.ReportMetric(float64(result.LatencyP50)/float64(time.Millisecond), "p50-ms")
+			b.ReportMetric(float64(result.LatencyP90)/float64(time.Millisecond), "p90-ms")
+			b.ReportMetric(float64(result.LatencyP99)/float64(time.Millisecond), "p99-ms")
+			b.ReportMetric(result.MsgsPerBlock(), "msgs/block")
+			b.ReportMetric(result.BytesPerBlock(), "bytes/block")
+			b.ReportMetric(0, "ns/op")
+		})
+	}
+}
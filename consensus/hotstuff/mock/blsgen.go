@@ -1,8 +1,12 @@
 package mock
 
 import (
+	"github.com/ethereum/go-ethereum/common"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/sign/bls"
 )
 
 func GenerateBLSKeys(n, f int) (*bn256.Suite, *share.PubPoly, *share.PriPoly) {
@@ -13,3 +17,22 @@ func GenerateBLSKeys(n, f int) (*bn256.Suite, *share.PubPoly, *share.PriPoly) {
 
 	return suite, pubPoly, priPoly
 }
+
+// GenerateMultiSigKeys generates an independent BLS key pair per validator, with
+// the proofs of possession required by the multi-signature scheme
+func GenerateMultiSigKeys(addrs []common.Address) (*bn256.Suite, []kyber.Scalar, []*snr.BLSPublicKey) {
+	suite := bn256.NewSuite()
+	privKeys := make([]kyber.Scalar, len(addrs))
+	pubKeys := make([]*snr.BLSPublicKey, len(addrs))
+	for i, addr := range addrs {
+		priv, pub := bls.NewKeyPair(suite, suite.RandomStream())
+		pubBytes, _ := pub.MarshalBinary()
+		proof, err := snr.NewBLSProof(suite, priv, pubBytes)
+		if err != nil {
+			panic(err)
+		}
+		privKeys[i] = priv
+		pubKeys[i] = &snr.BLSPublicKey{Address: addr, PublicKey: pubBytes, Proof: proof}
+	}
+	return suite, privKeys, pubKeys
+}
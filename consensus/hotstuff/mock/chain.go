@@ -1,7 +1,6 @@
 package mock
 
 import (
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -9,8 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-func makeChain(db ethdb.Database, engine consensus.Engine, validators []common.Address) *core.BlockChain {
-	genesis := makeGenesis(validators)
+func makeChain(db ethdb.Database, engine consensus.Engine, genesis *core.Genesis) *core.BlockChain {
 	block := genesis.MustCommit(db)
 	log.Info("Make chain with genesis block", "hash", block.Hash())
 
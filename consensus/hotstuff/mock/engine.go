@@ -1,14 +1,11 @@
 package mock
 
 import (
-	"crypto/ecdsa"
-
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
-	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethdb"
 )
 
@@ -16,15 +13,23 @@ type Engine consensus.Engine
 
 // backend is engine but also hotstuff engine and consensus handler.
 func makeEngine(
-	privateKey *ecdsa.PrivateKey,
+	signer hs.Signer,
 	db ethdb.Database,
-	validators []common.Address,
-	blsInfo *types.BLSInfo,
+	valset hs.ValidatorSet,
+	config *hs.Config,
 ) Engine {
-	config := hs.DefaultBasicConfig
-	valset := validator.NewSet(validators, hs.RoundRobin)
-	engine := backend.New(config, privateKey, db, valset, blsInfo)
+	engine := backend.NewWithSigner(config, db, valset, signer)
 	broadcaster := makeBroadcaster(engine.Address(), engine)
 	engine.SetBroadcaster(broadcaster)
 	return engine
 }
+
+func makeValSet(validators []common.Address, weights []int, config *hs.Config) hs.ValidatorSet {
+	if weights != nil {
+		if err := validator.CheckWeights(weights, config.LeaderPolicy); err != nil {
+			panic(err)
+		}
+		return validator.NewWeightedSet(validators, weights, config.LeaderPolicy)
+	}
+	return validator.NewSet(validators, config.LeaderPolicy)
+}
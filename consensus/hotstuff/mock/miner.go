@@ -1,7 +1,9 @@
 package mock
 
 import (
+	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -20,6 +22,7 @@ type miner struct {
 	chain  *core.BlockChain
 	engine consensus.MockHotStuff
 	geth   *Geth
+	txpool *txPool // optional source of transactions, blocks stay empty if nil
 
 	current *environment
 
@@ -29,6 +32,11 @@ type miner struct {
 	executedCh  chan consensus.ExecutedBlock
 	executedSub event.Subscription
 
+	speculativeCh   chan consensus.SpeculativeParent
+	speculativeSub  event.Subscription
+	speculative     *speculativeWork // Block built on a parent not committed yet
+	speculativeHits int32            // Speculative blocks sealed once their parent was committed, accessed atomically
+
 	pendingMu    sync.RWMutex
 	pendingTasks map[common.Hash]*task
 
@@ -49,20 +57,28 @@ type task struct {
 	block    *types.Block
 }
 
+// speculativeWork is a block built on a parent agreed on but not committed yet
+type speculativeWork struct {
+	env  *environment
+	task *task
+}
+
 func makeMiner(address common.Address, chain *core.BlockChain, engine consensus.MockHotStuff) *miner {
 	miner := &miner{
-		addr:         address,
-		chain:        chain,
-		engine:       engine,
-		headCh:       make(chan core.ChainHeadEvent, 1),
-		executedCh:   make(chan consensus.ExecutedBlock, 1),
-		pendingTasks: make(map[common.Hash]*task),
-		exit:         make(chan struct{}),
+		addr:          address,
+		chain:         chain,
+		engine:        engine,
+		headCh:        make(chan core.ChainHeadEvent, 1),
+		executedCh:    make(chan consensus.ExecutedBlock, 1),
+		speculativeCh: make(chan consensus.SpeculativeParent, 1),
+		pendingTasks:  make(map[common.Hash]*task),
+		exit:          make(chan struct{}),
 	}
 
 	handler := engine.(consensus.Handler)
 	miner.chainHeadSub = chain.SubscribeChainHeadEvent(miner.headCh)
 	miner.executedSub = handler.SubscribeBlock(miner.executedCh)
+	miner.speculativeSub = engine.(consensus.Speculator).SubscribeSpeculative(miner.speculativeCh)
 	return miner
 }
 
@@ -84,6 +100,9 @@ func (m *miner) Start() {
 		case data := <-m.executedCh:
 			m.commit(&data)
 
+		case parent := <-m.speculativeCh:
+			m.speculate(parent)
+
 			// ensure that backend nodes feed wont be blocked.
 		case <-m.exit:
 			log.Info("miner stopped")
@@ -95,11 +114,27 @@ func (m *miner) Start() {
 func (m *miner) Stop() {
 	m.chainHeadSub.Unsubscribe()
 	m.executedSub.Unsubscribe()
+	m.speculativeSub.Unsubscribe()
 	close(m.exit)
 }
 
 func (m *miner) newWork() {
 	parent := types.CopyHeader(m.chain.CurrentHeader())
+
+	// seal the block built while its parent was decided, if it was, and keep it while
+	// its parent may still be committed
+	if work := m.speculative; work != nil {
+		switch {
+		case work.task.block.ParentHash() == parent.Hash():
+			m.speculative = nil
+			atomic.AddInt32(&m.speculativeHits, 1)
+			m.current = work.env
+			m.seal(work.task)
+			return
+		case work.task.block.NumberU64() <= parent.Number.Uint64()+1:
+			m.speculative = nil
+		}
+	}
 	num := parent.Number
 	timestamp := time.Now().Unix()
 	log.Debug("Parent header", "hash", parent.Hash(), "num", num)
@@ -124,15 +159,56 @@ func (m *miner) newWork() {
 	}
 
 	s := m.current.state.Copy()
-	block, err := m.engine.FinalizeAndAssemble(m.chain, m.current.header, s, nil, nil, nil) // receipts are nil
+	txs, receipts := applyPoolTransactions(m.chain.Config(), m.chain, m.txpool, header, s, m.current.privstate)
+	block, err := m.engine.FinalizeAndAssemble(m.chain, m.current.header, s, txs, nil, receipts)
+	if err != nil {
+		log.Error("Failed to finalizeAndAssemble", "err", err)
+		return
+	}
+
+	m.seal(&task{receipts: receipts, state: s, block: block})
+}
+
+// speculate builds a block on a parent the engine agreed on while the parent is
+// committed, like the worker of the miner package
+func (m *miner) speculate(parent consensus.SpeculativeParent) {
+	privstate, err := m.chain.ExecutedPrivateState(parent.Block.Hash())
+	if err != nil {
+		log.Debug("Skipping speculative work", "parent", parent.Block.Hash(), "err", err)
+		return
+	}
+	chain := &speculativeChain{BlockChain: m.chain, parent: parent.Block.Header()}
+	header := &types.Header{
+		ParentHash: parent.Block.Hash(),
+		Number:     new(big.Int).Add(parent.Block.Number(), common.Big1),
+		GasLimit:   math.MaxUint64,
+		Time:       uint64(time.Now().Unix()),
+	}
+	if err := types.HotstuffHeaderFillWithValidators(header, nil); err != nil {
+		log.Error("Failed to fill header", "err", err)
+		return
+	}
+	if err := m.engine.Prepare(chain, header); err != nil {
+		log.Error("Failed to prepare", "err", err)
+		return
+	}
+
+	s := parent.State
+	txs, receipts := applyPoolTransactions(m.chain.Config(), chain, m.txpool, header, s, privstate)
+	block, err := m.engine.FinalizeAndAssemble(chain, header, s, txs, nil, receipts)
 	if err != nil {
 		log.Error("Failed to finalizeAndAssemble", "err", err)
 		return
 	}
+	m.speculative = &speculativeWork{
+		env:  &environment{header: header, state: s, privstate: privstate},
+		task: &task{receipts: receipts, state: s, block: block},
+	}
+}
 
-	sealHash := m.engine.SealHash(block.Header())
+func (m *miner) seal(task *task) {
+	sealHash := m.engine.SealHash(task.block.Header())
 	m.pendingMu.Lock()
-	task := &task{receipts: nil, state: s, block: block}
 	m.pendingTasks[sealHash] = task
 	m.pendingMu.Unlock()
 
@@ -142,6 +218,19 @@ func (m *miner) newWork() {
 	}
 }
 
+// speculativeChain is the chain extended by a parent block which is not committed yet
+type speculativeChain struct {
+	*core.BlockChain
+	parent *types.Header
+}
+
+func (c *speculativeChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if hash == c.parent.Hash() {
+		return c.parent
+	}
+	return c.BlockChain.GetHeader(hash, number)
+}
+
 func (m *miner) commit(data *consensus.ExecutedBlock) {
 	block := data.Block
 	if block == nil {
@@ -0,0 +1,144 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// makeCensorshipSystem creates a system whose first holders replicas hold a
+// transaction in their pool which no leader ever includes, since miners build empty
+// blocks. The other replicas have an empty pool.
+func makeCensorshipSystem(t *testing.T, n int, holders int, config *hs.Config) *System {
+	var (
+		keys, alloc          = newWorkloadAccounts(1)
+		pool                 = newTxPool()
+		pks, blsinfos, addrs = newAccountLists(n)
+		nodes                = make([]*Geth, n)
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, config, alloc)
+		if i < holders {
+			nodes[i].engine.(consensus.TxPoolHandler).SetTxPool(pool)
+		} else {
+			nodes[i].engine.(consensus.TxPoolHandler).SetTxPool(newTxPool())
+		}
+	}
+
+	tx, err := types.SignTx(types.NewTransaction(0, workloadSink, common.Big1, params.TxGas, common.Big0, nil), types.LatestSigner(nodes[0].chain.Config()), keys[0])
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	pool.Add(tx)
+	return &System{nodes: nodes, exit: make(chan struct{})}
+}
+
+func censorshipSuspects(node *Geth) map[common.Address]uint64 {
+	return node.engine.(interface {
+		CensorshipSuspects() map[common.Address]uint64
+	}).CensorshipSuspects()
+}
+
+// headNode returns the node with the longest chain. The mock network does not sync
+// blocks, so a node which falls behind a view change may never catch up.
+func headNode(sys *System) *Geth {
+	head := sys.nodes[0]
+	for _, node := range sys.nodes[1:] {
+		if node.chain.CurrentHeader().Number.Cmp(head.chain.CurrentHeader().Number) > 0 {
+			head = node
+		}
+	}
+	return head
+}
+
+// TestCensorshipFlag checks that leaders leaving out a pending transaction are
+// suspected after the configured number of proposals, and still commit their blocks
+func TestCensorshipFlag(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.CensorshipViews = 2
+
+	sys := makeCensorshipSystem(t, 4, 4, &config)
+	sys.Start()
+	sys.Close(10)
+
+	node := headNode(sys)
+	head := node.chain.CurrentHeader().Number.Uint64()
+	if head < 6 {
+		t.Fatalf("too few blocks committed, expected at least 6, got %d", head)
+	}
+	suspects := censorshipSuspects(node)
+	if len(suspects) == 0 {
+		t.Fatalf("no leader suspected of censorship")
+	}
+	committed := 0
+	for n := uint64(1); n <= head; n++ {
+		if suspects[node.chain.GetHeaderByNumber(n).Coinbase] > 0 {
+			committed++
+		}
+	}
+	if committed == 0 {
+		t.Errorf("flagged leaders should still commit their blocks")
+	}
+}
+
+// TestCensorshipViewChange checks that replicas refuse the proposal of a suspected
+// leader and, once more than F of them suspect it, move to the next round long
+// before the view times out
+func TestCensorshipViewChange(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.RequestTimeout = 30000
+	config.CensorshipViews = 1
+	config.CensorshipAction = hs.ViewChangeCensorship
+
+	sys := makeCensorshipSystem(t, 4, 4, &config)
+	sys.Start()
+	sys.Close(10)
+
+	// the first block leaves out the transaction once, the second proposal is refused
+	node := headNode(sys)
+	head, changed := commitRounds(t, node)
+	if head < 2 {
+		t.Fatalf("too few blocks committed, expected at least 2, got %d", head)
+	}
+	if changed == 0 {
+		t.Errorf("expected blocks committed after an early view change, got none in %d blocks", head)
+	}
+	if suspects := censorshipSuspects(node); len(suspects) == 0 {
+		t.Errorf("no leader suspected of censorship")
+	}
+}
+
+// TestCensorshipViewChangeMinority checks that a single replica suspecting the leader
+// neither changes the view on its own nor keeps the others from committing in round 0
+func TestCensorshipViewChangeMinority(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.RequestTimeout = 30000
+	config.CensorshipViews = 1
+	config.CensorshipAction = hs.ViewChangeCensorship
+
+	// F = 1: the suspicion of the only replica holding the transaction is not enough
+	sys := makeCensorshipSystem(t, 4, 1, &config)
+	sys.Start()
+	sys.Close(10)
+
+	head, changed := commitRounds(t, headNode(sys))
+	if head < 2 {
+		t.Fatalf("too few blocks committed, expected at least 2, got %d", head)
+	}
+	if changed != 0 {
+		t.Errorf("view changed by a single suspicion, %d of %d blocks committed after round 0", changed, head)
+	}
+	if suspects := censorshipSuspects(sys.nodes[0]); len(suspects) == 0 {
+		t.Errorf("no leader suspected of censorship by the replica holding the transaction")
+	}
+	if status := sys.nodes[0].engine.(interface{ Status() *hs.Status }).Status(); status.RoundChanges != 0 {
+		t.Errorf("suspecting replica changed the view on its own %d times", status.RoundChanges)
+	}
+}
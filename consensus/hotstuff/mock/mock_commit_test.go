@@ -2,12 +2,16 @@ package mock
 
 import (
 	"math/big"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -817,3 +821,127 @@ func TestCommitVoteFaultyPayloadBad(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// TestCommitFinalizedHeader checks that committed blocks are reported final, and
+// that a header without a valid commit QC is not.
+func TestCommitFinalizedHeader(t *testing.T) {
+	sys := makeSystem(4)
+	sys.Start()
+	sys.Close(10)
+
+	// a head whose QC is stripped falls back to its parent. The block hash does not
+	// cover the QC, so this runs before the head is cached as finalized.
+	node := sys.nodes[0]
+	head := types.CopyHeader(node.chain.CurrentHeader())
+	if head.Number.Uint64() == 0 {
+		t.Fatalf("no block committed")
+	}
+	if err := head.SetEncodedQC(nil); err != nil {
+		t.Fatalf("failed to strip QC: %v", err)
+	}
+	chain := &headerChain{ChainHeaderReader: node.chain, head: head}
+	finalized := node.engine.(consensus.FinalityReader).FinalizedHeader(chain)
+	if finalized == nil || finalized.Hash() != head.ParentHash {
+		t.Errorf("expected the parent of an unsealed head to be finalized, got %v", finalized)
+	}
+
+	for _, node := range sys.nodes {
+		head := node.chain.CurrentHeader()
+		finalized := node.engine.(consensus.FinalityReader).FinalizedHeader(node.chain)
+		if finalized == nil || finalized.Hash() != head.Hash() {
+			t.Errorf("finalized header mismatch, node: %v, head: %d, finalized: %v", node.addr, head.Number, finalized)
+		}
+	}
+}
+
+// headerChain overrides the head of a chain
+type headerChain struct {
+	consensus.ChainHeaderReader
+	head *types.Header
+}
+
+func (c *headerChain) CurrentHeader() *types.Header { return c.head }
+
+// TestCommitAudit checks that the offline auditor accepts a committed chain, and
+// reports tampered, replayed and missing headers.
+func TestCommitAudit(t *testing.T) {
+	sys := makeSystem(4)
+	sys.Start()
+	sys.Close(10)
+
+	node := sys.nodes[0]
+	head := node.chain.CurrentHeader().Number.Uint64()
+	if head < 4 {
+		t.Fatalf("too few blocks committed, expected at least 4, got %d", head)
+	}
+	valset := node.engine.(*backend.Backend).Validators()
+	report := backend.Audit(node.chain, node.signer, valset, 0, head)
+	if report.Headers != head || len(report.Issues) != 0 {
+		t.Fatalf("unexpected audit result, headers: %d, issues: %v", report.Headers, report.Issues)
+	}
+
+	// header 2 carries the QC of header 1, header 3 is missing
+	replayed := types.CopyHeader(node.chain.GetHeaderByNumber(2))
+	extra, err := types.ExtractHotstuffExtra(node.chain.GetHeaderByNumber(1))
+	if err != nil {
+		t.Fatalf("failed to extract extra: %v", err)
+	}
+	if err := replayed.SetEncodedQC(extra.EncodedQC); err != nil {
+		t.Fatalf("failed to replace QC: %v", err)
+	}
+	chain := &auditChain{chain: node.chain, headers: map[uint64]*types.Header{2: replayed, 3: nil}}
+
+	report = backend.Audit(chain, node.signer, valset, 1, 4)
+	reasons := make(map[uint64][]string)
+	for _, issue := range report.Issues {
+		reasons[issue.Number] = append(reasons[issue.Number], issue.Reason)
+	}
+	if len(reasons[1]) != 0 || len(reasons[2]) == 0 || len(reasons[3]) != 1 || len(reasons[4]) != 0 {
+		t.Errorf("unexpected audit issues: %v", report.Issues)
+	}
+	if report.Headers != 3 {
+		t.Errorf("expected 3 headers audited, got %d", report.Headers)
+	}
+
+	// headers 2 and 3 record the same forged set, which is checked against the
+	// configured set rather than against the previous header
+	forged := []common.Address{node.addr}
+	headers := make(map[uint64]*types.Header)
+	for _, n := range []uint64{2, 3} {
+		header := types.CopyHeader(node.chain.GetHeaderByNumber(n))
+		extra, err := types.ExtractHotstuffExtra(header)
+		if err != nil {
+			t.Fatalf("failed to extract extra: %v", err)
+		}
+		extra.Validators = forged
+		payload, err := rlp.EncodeToBytes(extra)
+		if err != nil {
+			t.Fatalf("failed to encode extra: %v", err)
+		}
+		header.Extra = append(header.Extra[:types.HotstuffExtraVanity:types.HotstuffExtraVanity], payload...)
+		headers[n] = header
+	}
+	report = backend.Audit(&auditChain{chain: node.chain, headers: headers}, node.signer, valset, 1, 4)
+	mismatches := make(map[uint64]bool)
+	for _, issue := range report.Issues {
+		if strings.HasPrefix(issue.Reason, "validator set mismatch") {
+			mismatches[issue.Number] = true
+		}
+	}
+	if !mismatches[2] || !mismatches[3] || mismatches[4] {
+		t.Errorf("expected validator set mismatches at blocks 2 and 3, got %v", report.Issues)
+	}
+}
+
+// auditChain overrides headers of a chain, a nil header is missing
+type auditChain struct {
+	chain   backend.AuditChain
+	headers map[uint64]*types.Header
+}
+
+func (c *auditChain) GetHeaderByNumber(number uint64) *types.Header {
+	if header, ok := c.headers[number]; ok {
+		return header
+	}
+	return c.chain.GetHeaderByNumber(number)
+}
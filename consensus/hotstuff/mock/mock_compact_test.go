@@ -0,0 +1,81 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+)
+
+// makeCompactSystem builds a network proposing compact blocks out of a shared pool,
+// while replicas rebuild them out of the pool returned by replicaPool
+func makeCompactSystem(n int, replicaPool func(shared *txPool) *txPool) (*System, *txFeeder) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.CompactProposal = true
+
+	var (
+		keys, alloc          = newWorkloadAccounts(4)
+		pool                 = newTxPool()
+		pks, blsinfos, addrs = newAccountLists(n)
+		nodes                = make([]*Geth, n)
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, alloc)
+		nodes[i].miner.txpool = pool
+		nodes[i].engine.(consensus.TxPoolHandler).SetTxPool(replicaPool(pool))
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	return sys, newTxFeeder(nodes[0].chain.Config(), keys, 50, pool)
+}
+
+func checkCompactCommit(t *testing.T, sys *System) {
+	var (
+		head = sys.nodes[0].chain.CurrentHeader().Number.Uint64()
+		txs  int
+	)
+	for _, node := range sys.nodes[1:] {
+		if n := node.chain.CurrentHeader().Number.Uint64(); n < head {
+			head = n
+		}
+	}
+	if head < 5 {
+		t.Fatalf("too few blocks committed, expected at least 5, got %d", head)
+	}
+	for n := uint64(1); n <= head; n++ {
+		block := sys.nodes[0].chain.GetBlockByNumber(n)
+		for _, node := range sys.nodes[1:] {
+			if hash := node.chain.GetHeaderByNumber(n).Hash(); hash != block.Hash() {
+				t.Fatalf("chains diverged, number: %d, expected %v, got %v", n, block.Hash(), hash)
+			}
+		}
+		txs += len(block.Transactions())
+	}
+	if txs == 0 {
+		t.Errorf("no transactions committed")
+	}
+}
+
+func TestCompactProposalCommit(t *testing.T) {
+	sys, feeder := makeCompactSystem(4, func(shared *txPool) *txPool { return shared })
+	feeder.Start()
+	sys.Start()
+	sys.Close(15)
+	feeder.Stop()
+
+	checkCompactCommit(t, sys)
+	if _, timeouts := commitRounds(t, sys.nodes[0]); timeouts > 0 {
+		t.Errorf("unexpected timeouts, got %d", timeouts)
+	}
+}
+
+func TestCompactProposalFetch(t *testing.T) {
+	// replicas know none of the proposed transactions and fetch them all from the leader
+	sys, feeder := makeCompactSystem(4, func(shared *txPool) *txPool { return newTxPool() })
+	feeder.Start()
+	sys.Start()
+	sys.Close(15)
+	feeder.Stop()
+
+	checkCompactCommit(t, sys)
+}
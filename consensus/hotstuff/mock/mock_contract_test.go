@@ -0,0 +1,201 @@
+package mock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend/contract"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// validatorContractCode returns the code of a validator contract whose functions
+// return the initial validators while the block number is below join, and all of
+// them from then on. The ABI encoded results are appended to the code.
+func validatorContractCode(initial, joined []*snr.BLSPublicKey, join uint64) ([]byte, error) {
+	var (
+		abi     = contract.HotStuffValidatorContractInterfaceParsedABI
+		results [][]byte
+	)
+	for _, method := range []string{"getValidators", "getBLSPublicKeys"} {
+		for _, keys := range [][]*snr.BLSPublicKey{initial, joined} {
+			var (
+				vals    = make([]common.Address, len(keys))
+				pubKeys = make([][]byte, len(keys))
+				proofs  = make([][]byte, len(keys))
+			)
+			for i, key := range keys {
+				vals[i], pubKeys[i], proofs[i] = key.Address, key.PublicKey, key.Proof
+			}
+			var (
+				result []byte
+				err    error
+			)
+			if method == "getValidators" {
+				result, err = abi.Methods[method].Outputs.Pack(vals)
+			} else {
+				result, err = abi.Methods[method].Outputs.Pack(pubKeys, proofs)
+			}
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, result)
+		}
+	}
+
+	// the result index is 2 * (selector == getBLSPublicKeys) + (number >= join), and
+	// selects one of the entries copying a result to memory and returning it
+	const (
+		dispatchSize = 30
+		entrySize    = 16
+	)
+	sel := abi.Methods["getBLSPublicKeys"].ID
+	code := []byte{
+		byte(vm.PUSH1), 0, byte(vm.CALLDATALOAD), byte(vm.PUSH1), 0xe0, byte(vm.SHR),
+		byte(vm.PUSH4), sel[0], sel[1], sel[2], sel[3], byte(vm.EQ),
+		byte(vm.PUSH1), 2, byte(vm.MUL),
+		byte(vm.PUSH2), byte(join >> 8), byte(join), byte(vm.NUMBER), byte(vm.LT), byte(vm.ISZERO), byte(vm.ADD),
+		byte(vm.PUSH1), entrySize, byte(vm.MUL), byte(vm.PUSH2), 0, dispatchSize, byte(vm.ADD), byte(vm.JUMP),
+	}
+	offset := dispatchSize + entrySize*len(results)
+	for _, result := range results {
+		size := len(result)
+		code = append(code,
+			byte(vm.JUMPDEST),
+			byte(vm.PUSH2), byte(size>>8), byte(size), byte(vm.PUSH2), byte(offset>>8), byte(offset), byte(vm.PUSH1), 0, byte(vm.CODECOPY),
+			byte(vm.PUSH2), byte(size>>8), byte(size), byte(vm.PUSH1), 0, byte(vm.RETURN),
+		)
+		offset += size
+	}
+	for _, result := range results {
+		code = append(code, result...)
+	}
+	return code, nil
+}
+
+// relayBlocks imports the blocks committed by src into dst, standing in for the block
+// sync of a node outside the validator set
+func relayBlocks(src, dst *Geth) func() {
+	headCh := make(chan core.ChainHeadEvent, 64)
+	sub := src.chain.SubscribeChainHeadEvent(headCh)
+	go func() {
+		for {
+			select {
+			case ev := <-headCh:
+				block, head := ev.Block, dst.chain.CurrentBlock()
+				if block.NumberU64() != head.NumberU64()+1 || block.ParentHash() != head.Hash() {
+					continue
+				}
+				statedb, receipts, logs, err := dst.chain.ExecuteBlock(block)
+				if err != nil {
+					log.Error("Failed to execute relayed block", "number", block.Number(), "err", err)
+					continue
+				}
+				_, privstate, _ := dst.chain.StateAt(head.Root())
+				if _, err := dst.chain.WriteBlockWithState(block, receipts, logs, statedb, privstate, true); err != nil {
+					log.Error("Failed to write relayed block", "number", block.Number(), "err", err)
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub.Unsubscribe
+}
+
+func TestValidatorContractJoin(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.SignatureScheme = hs.MultiBLS
+
+	// the last validator joins at block 4, its BLS key only known to the contract
+	const join = 3
+	pks, _, addrs := newAccountLists(6)
+	suite, blsKeys, pubKeys := GenerateMultiSigKeys(addrs)
+	code, err := validatorContractCode(pubKeys[:4], pubKeys[:5], join)
+	if err != nil {
+		t.Fatalf("failed to build validator contract: %v", err)
+	}
+	config.ValidatorContract = common.HexToAddress("0x0000000000000000000000000000000000001000")
+	alloc := core.GenesisAlloc{config.ValidatorContract: {Code: code, Balance: new(big.Int)}}
+
+	// the last account is not a validator, and syncs the chain once it is committed
+	nodes := make([]*Geth, len(pks))
+	for i := range nodes {
+		valset := makeValSet(addrs[:4], nil, &config)
+		signer, err := snr.NewMultiSigner(pks[i], byte(hs.MsgTypePrepareVote), suite, blsKeys[i], pubKeys[:4], valset)
+		if err != nil {
+			t.Fatalf("failed to create multi-signer: %v", err)
+		}
+		nodes[i] = makeGethWithValSet(signer, valset, addrs[:4], &config, alloc)
+	}
+	syncer := nodes[5]
+	sys := &System{nodes: nodes[:5], exit: make(chan struct{})}
+	stop := relayBlocks(nodes[0], nodes[4])
+
+	sys.Start()
+	sys.Close(20)
+	stop()
+
+	var (
+		chain  = sys.nodes[0].chain
+		signer = sys.nodes[0].signer
+		head   = chain.CurrentHeader().Number.Uint64()
+		joined bool
+	)
+	if head < join+5 {
+		t.Fatalf("too few blocks committed, expected at least %d, got %d", join+5, head)
+	}
+	for n := uint64(1); n <= head; n++ {
+		header := chain.GetHeaderByNumber(n)
+		extra, err := types.ExtractHotstuffExtra(header)
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n, err)
+		}
+		// the set governing a block is read at the state of its parent
+		want := 4
+		if n > join {
+			want = 5
+		}
+		if len(extra.Validators) != want {
+			t.Errorf("validator set mismatch, number: %d, have %d validators, want %d", n, len(extra.Validators), want)
+		}
+		proposer, err := signer.RecoverSigner(header)
+		if err != nil {
+			t.Fatalf("failed to recover proposer, number: %d, err: %v", n, err)
+		}
+		if proposer == addrs[4] {
+			if n <= join {
+				t.Errorf("block proposed by a validator before it joined, number: %d", n)
+			}
+			joined = true
+		}
+	}
+	if !joined {
+		t.Errorf("joining validator never proposed")
+	}
+	if size := sys.nodes[4].engine.(*backend.Backend).Validators().Size(); size != 5 {
+		t.Errorf("joining node validator set mismatch, have %d, want %d", size, 5)
+	}
+
+	// headers of the first epoch still verify with the set which signed them
+	if err := nodes[0].engine.VerifyHeader(chain, chain.GetHeaderByNumber(join), true); err != nil {
+		t.Errorf("header of the first epoch failed to verify: %v", err)
+	}
+
+	// a node syncing the chain verifies every header with the set of its epoch
+	blocks := make(types.Blocks, 0, head)
+	for n := uint64(1); n <= head; n++ {
+		blocks = append(blocks, chain.GetBlockByNumber(n))
+	}
+	if n, err := syncer.chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to sync block %d: %v", blocks[n].Number(), err)
+	}
+	syncer.chain.Stop()
+}
@@ -268,3 +268,49 @@ func TestDecideFaultyDiplomaQCBlockBad(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestDecideWritesExecutedState(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	var (
+		keys, alloc          = newWorkloadAccounts(4)
+		pool                 = newTxPool()
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, 4)
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, alloc)
+		nodes[i].miner.txpool = pool
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	feeder := newTxFeeder(nodes[0].chain.Config(), keys, 50, pool)
+
+	feeder.Start()
+	sys.Start()
+	sys.Close(10)
+	feeder.Stop()
+
+	// replicas write the state executed in Prepare, along with the receipts
+	txs := 0
+	for _, node := range sys.nodes {
+		head := node.chain.CurrentBlock()
+		if head.NumberU64() < 4 {
+			t.Fatalf("too few blocks committed, expected at least 4, got %d", head.NumberU64())
+		}
+		if _, _, err := node.chain.StateAt(head.Root()); err != nil {
+			t.Errorf("missing head state, node: %v, err: %v", node.addr, err)
+		}
+		for n := uint64(1); n <= head.NumberU64(); n++ {
+			block := node.chain.GetBlockByNumber(n)
+			receipts := node.chain.GetReceiptsByHash(block.Hash())
+			if len(receipts) != len(block.Transactions()) {
+				t.Errorf("receipts mismatch, node: %v, number: %d, expected %d, got %d", node.addr, n, len(block.Transactions()), len(receipts))
+			}
+			txs += len(block.Transactions())
+		}
+	}
+	if txs == 0 {
+		t.Errorf("no transactions committed")
+	}
+}
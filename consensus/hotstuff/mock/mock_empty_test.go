@@ -0,0 +1,52 @@
+package mock
+
+import (
+	"testing"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TestEmptyBlockPeriod checks that empty blocks are held back until the empty block
+// period passed, and that replicas reject empty blocks sealed earlier
+func TestEmptyBlockPeriod(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.EmptyBlockPeriod = 3
+
+	var (
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	sys.Close(10)
+
+	node := headNode(sys)
+	head := node.chain.CurrentHeader()
+	if head.Number.Uint64() < 2 {
+		t.Fatalf("chain reached block %d, want at least 2", head.Number)
+	}
+	if head.Number.Uint64() > 4 {
+		t.Errorf("chain reached block %d in 10 seconds with an empty block period of 3", head.Number)
+	}
+	for number := uint64(2); number <= head.Number.Uint64(); number++ {
+		header, parent := node.chain.GetHeaderByNumber(number), node.chain.GetHeaderByNumber(number-1)
+		if header.TxHash != types.EmptyRootHash {
+			t.Fatalf("block %d is not empty", number)
+		}
+		if header.Time < parent.Time+config.EmptyBlockPeriod {
+			t.Errorf("empty block %d at %d, parent at %d", number, header.Time, parent.Time)
+		}
+	}
+
+	// an empty block following its parent by the block period only is rejected
+	header := types.CopyHeader(head)
+	header.Time = node.chain.GetHeaderByNumber(head.Number.Uint64()-1).Time + config.BlockPeriod
+	if err := node.engine.VerifyHeader(node.chain, header, false); err != hs.ErrInvalidTimestamp {
+		t.Errorf("early empty block verified with %v, want %v", err, hs.ErrInvalidTimestamp)
+	}
+}
@@ -0,0 +1,152 @@
+package mock
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+)
+
+func healthOf(t *testing.T, node *Geth) (int, *backend.Health) {
+	handler := node.engine.(interface{ HealthHandler() http.Handler }).HealthHandler()
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/hotstuff/health", nil))
+
+	health := new(backend.Health)
+	if err := json.NewDecoder(rec.Body).Decode(health); err != nil {
+		t.Fatalf("invalid health body: %v", err)
+	}
+	return rec.Code, health
+}
+
+// TestHealth checks that nodes making progress report healthy, and that a node
+// requiring more validators than connected reports why it is not
+func TestHealth(t *testing.T) {
+	var (
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+		configs              = make([]hs.Config, len(pks))
+	)
+	for i := range nodes {
+		configs[i] = *hs.DefaultBasicConfig
+		configs[i].BlockPeriod = 1
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &configs[i], nil)
+	}
+	configs[0].HealthPeers = 5
+	configs[0].HealthCommitPeriods = 0
+
+	if code, health := healthOf(t, nodes[1]); code != http.StatusServiceUnavailable || health.Healthy {
+		t.Errorf("node not started reported healthy, status %d", code)
+	}
+
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	time.Sleep(4 * time.Second)
+
+	node := headNode(sys)
+	if node == nodes[0] {
+		node = nodes[1]
+	}
+	if code, health := healthOf(t, node); code != http.StatusOK || !health.Healthy {
+		t.Errorf("progressing node reported unhealthy, status %d, reasons %v", code, health.Reasons)
+	} else if health.Validators != 4 || health.VotingPower != 4 || health.Quorum != 3 || health.Height == 0 {
+		t.Errorf("unexpected health %+v", health)
+	}
+
+	code, health := healthOf(t, nodes[0])
+	if code != http.StatusServiceUnavailable || health.Healthy {
+		t.Errorf("node requiring 5 validators reported healthy, status %d", code)
+	}
+	if len(health.Reasons) != 1 || !strings.Contains(health.Reasons[0], "4 validators connected, 5 required") {
+		t.Errorf("unexpected reasons %v", health.Reasons)
+	}
+	sys.Close(0)
+}
+
+// TestHealthVotingPower checks that the validators connected are weighed against the
+// quorum, rather than counted
+func TestHealthVotingPower(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.HealthCommitPeriods = 0
+	config.HealthMaxRound = 0
+
+	// total weight 6 and Q = 5: three light validators form a majority by count, but
+	// hold a voting power of 3 only without the heavy one
+	// the heavy validator is not connected to the second one
+	sys := makeWeightedSystem([]int{3, 1, 1, 1}, &config)
+	for i, node := range sys.nodes {
+		for _, peer := range sys.nodes[i+1:] {
+			if i != 0 || peer != sys.nodes[1] {
+				node.broadcaster.Connect(peer.broadcaster)
+			}
+		}
+	}
+	for _, node := range sys.nodes {
+		go node.Start()
+	}
+	time.Sleep(2 * time.Second)
+
+	if code, health := healthOf(t, sys.nodes[2]); code != http.StatusOK || health.VotingPower != 6 || health.Quorum != 5 {
+		t.Errorf("fully connected node reported unhealthy, status %d, health %+v", code, health)
+	}
+	code, health := healthOf(t, sys.nodes[1])
+	if code != http.StatusServiceUnavailable || health.Validators != 3 || health.VotingPower != 3 {
+		t.Errorf("node without the heavy validator reported healthy, status %d, health %+v", code, health)
+	}
+	if len(health.Reasons) != 1 || !strings.Contains(health.Reasons[0], "voting power 3 connected, quorum 5") {
+		t.Errorf("unexpected reasons %v", health.Reasons)
+	}
+	for _, node := range sys.nodes {
+		node.Stop()
+	}
+}
+
+// unhealthyCommits polls the health of node for the given duration, and returns the
+// number of polls which reported a missing commit
+func unhealthyCommits(t *testing.T, node *Geth, duration time.Duration) int {
+	count := 0
+	for end := time.Now().Add(duration); time.Now().Before(end); time.Sleep(250 * time.Millisecond) {
+		_, health := healthOf(t, node)
+		for _, reason := range health.Reasons {
+			if strings.Contains(reason, "no block committed") {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// TestHealthEmptyPeriod checks that empty blocks held back for the empty block period
+// are not reported as missing commits, unless transactions wait in the pool
+func TestHealthEmptyPeriod(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.EmptyBlockPeriod = 3
+	config.HealthCommitPeriods = 2
+	config.HealthMaxRound = 0
+
+	// the pool is empty: the limit is 2 empty block periods, i.e. 6 seconds
+	sys := makeSystemWithConfig(4, &config)
+	sys.Start()
+	time.Sleep(4 * time.Second)
+	if count := unhealthyCommits(t, sys.nodes[0], 7*time.Second); count != 0 {
+		t.Errorf("empty blocks reported as missing commits %d times", count)
+	}
+	sys.Close(0)
+
+	// a transaction which is never included waits in the pool: the limit is 2 block
+	// periods, which the empty block period of 3 seconds exceeds
+	sys = makeCensorshipSystem(t, 4, 4, &config)
+	sys.Start()
+	time.Sleep(4 * time.Second)
+	if count := unhealthyCommits(t, sys.nodes[0], 7*time.Second); count == 0 {
+		t.Errorf("missing commits not reported while transactions are pending")
+	}
+	sys.Close(0)
+}
@@ -0,0 +1,121 @@
+package mock
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestLeaderReputationSkipsSilent(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.LeaderPolicy = hs.Reputation
+	config.BlockPeriod = 1
+	config.RequestTimeout = 2000
+
+	sys := makeSystemWithConfig(4, &config)
+
+	// silent node never sends consensus messages, as if it were offline
+	silent := sys.nodes[3]
+	silent.setHook(func(node *Geth, data []byte) ([]byte, bool) {
+		return data, false
+	})
+
+	sys.Start()
+	sys.Close(40)
+
+	var (
+		chain     = sys.nodes[0].chain
+		head      = chain.CurrentHeader().Number.Uint64()
+		timeouts  = 0
+		proposals = 0
+	)
+	for n := uint64(1); n <= head; n++ {
+		header := chain.GetHeaderByNumber(n)
+		extra, err := types.ExtractHotstuffExtra(header)
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n, err)
+		}
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+			t.Fatalf("failed to decode commitQC, number: %d, err: %v", n, err)
+		}
+		if qc.View.Round.Uint64() > 0 {
+			timeouts++
+		}
+		if header.Coinbase == silent.addr {
+			proposals++
+		}
+	}
+
+	if head < 8 {
+		t.Fatalf("too few blocks committed, expected at least 8, got %d", head)
+	}
+	if proposals != 0 {
+		t.Errorf("silent node should never propose, got %d blocks", proposals)
+	}
+	// the silent node may only stall the network once before its reputation drops
+	if timeouts > 1 {
+		t.Errorf("expected at most 1 timed out height, got %d in %d blocks", timeouts, head)
+	}
+}
+
+// TestLeaderStatus checks the consensus state reported to monitoring services,
+// with a silent node forcing round changes whenever it leads.
+func TestLeaderStatus(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.RequestTimeout = 2000
+
+	sys := makeSystemWithConfig(4, &config)
+	silent := sys.nodes[3]
+	silent.setHook(func(node *Geth, data []byte) ([]byte, bool) {
+		return data, false
+	})
+
+	sys.Start()
+	time.Sleep(20 * time.Second)
+
+	for _, node := range sys.nodes[:3] {
+		status := node.engine.(interface{ Status() *hs.Status }).Status()
+		if !status.IsValidator {
+			t.Errorf("node %v not reported as validator", node.addr)
+		}
+		if status.Height == 0 || status.Leader == (common.Address{}) {
+			t.Errorf("invalid view, node: %v, height: %d, leader: %v", node.addr, status.Height, status.Leader)
+		}
+		if status.RoundChanges == 0 {
+			t.Errorf("expected round changes behind the silent node, node: %v", node.addr)
+		}
+		if status.QCParticipants < Q(len(sys.nodes)) {
+			t.Errorf("too few QC participants, node: %v, got %d", node.addr, status.QCParticipants)
+		}
+		if status.LastCommit.IsZero() || time.Since(status.LastCommit) > 10*time.Second {
+			t.Errorf("stale last commit, node: %v, time: %v", node.addr, status.LastCommit)
+		}
+	}
+	sys.Stop()
+	time.Sleep(time.Second)
+}
+
+// TestLeaderPolicyWeights checks that only the RoundRobin policy accepts unequal
+// validator weights, as the others rotate through validators regardless of them
+func TestLeaderPolicyWeights(t *testing.T) {
+	for _, policy := range []hs.SelectProposerPolicy{hs.RoundRobin, hs.Sticky, hs.Reputation} {
+		if err := validator.CheckWeights([]int{2, 2, 2, 2}, policy); err != nil {
+			t.Errorf("%s: equal weights refused: %v", policy, err)
+		}
+		err := validator.CheckWeights([]int{3, 1, 1, 1}, policy)
+		if policy == hs.RoundRobin && err != nil {
+			t.Errorf("%s: unequal weights refused: %v", policy, err)
+		}
+		if policy != hs.RoundRobin && !errors.Is(err, validator.ErrUnweightedPolicy) {
+			t.Errorf("%s: unequal weights accepted, err: %v", policy, err)
+		}
+	}
+}
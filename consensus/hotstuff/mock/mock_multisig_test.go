@@ -0,0 +1,145 @@
+package mock
+
+import (
+	"testing"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestMultiSigCommit(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	// one node stays silent, so every QC is signed by a strict subset of the set
+	sys := makeMultiSigSystem(4, &config)
+	silent := sys.nodes[3]
+	silent.setHook(func(node *Geth, data []byte) ([]byte, bool) {
+		return data, false
+	})
+
+	sys.Start()
+	sys.Close(20)
+
+	var (
+		chain  = sys.nodes[0].chain
+		signer = sys.nodes[0].signer.(*snr.MultiSigner)
+		head   = chain.CurrentHeader().Number.Uint64()
+	)
+	if head < 4 {
+		t.Fatalf("too few blocks committed, expected at least 4, got %d", head)
+	}
+	for n := uint64(1); n <= head; n++ {
+		header := chain.GetHeaderByNumber(n)
+		extra, err := types.ExtractHotstuffExtra(header)
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n, err)
+		}
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+			t.Fatalf("failed to decode commitQC, number: %d, err: %v", n, err)
+		}
+		if err := signer.AuthQC(qc); err != nil {
+			t.Errorf("invalid commitQC, number: %d, err: %v", n, err)
+		}
+		signers, err := signer.Signers(qc)
+		if err != nil {
+			t.Fatalf("failed to decode signers, number: %d, err: %v", n, err)
+		}
+		if len(signers) < Q(4) {
+			t.Errorf("commitQC signers below quorum, number: %d, got %d", n, len(signers))
+		}
+		for _, addr := range signers {
+			if addr == silent.addr {
+				t.Errorf("silent node listed as commitQC signer, number: %d", n)
+			}
+		}
+	}
+}
+
+func TestMultiSigKeyVote(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.SignatureScheme = hs.MultiBLS
+
+	// the key of the last validator is missing from genesis, and is registered by
+	// the votes of the other validators. Until then its votes are left out of QCs.
+	n := 4
+	pks, _, addrs := newAccountLists(n)
+	suite, blsKeys, pubKeys := GenerateMultiSigKeys(addrs)
+	joining := pubKeys[n-1]
+
+	sys := &System{nodes: make([]*Geth, n), exit: make(chan struct{})}
+	for i := 0; i < n; i++ {
+		valset := makeValSet(addrs, nil, &config)
+		signer, err := snr.NewMultiSigner(pks[i], byte(hs.MsgTypePrepareVote), suite, blsKeys[i], pubKeys[:n-1], valset)
+		if err != nil {
+			t.Fatalf("failed to create signer: %v", err)
+		}
+		sys.nodes[i] = makeGethWithValSet(signer, valset, addrs, &config, nil)
+		if i < n-1 {
+			if err := sys.nodes[i].api.ProposeBLSKey(joining.Address, joining.PublicKey, joining.Proof); err != nil {
+				t.Fatalf("failed to propose key: %v", err)
+			}
+		}
+	}
+
+	sys.Start()
+	sys.Close(20)
+
+	var (
+		node   = sys.nodes[0]
+		signer = node.signer.(*snr.MultiSigner)
+		head   = node.chain.CurrentHeader().Number.Uint64()
+	)
+	if head < 8 {
+		t.Fatalf("too few blocks committed, expected at least 8, got %d", head)
+	}
+	for i, node := range sys.nodes {
+		if !node.signer.(*snr.MultiSigner).HasPublicKey(joining.Address) {
+			t.Fatalf("voted key not registered by node %d", i)
+		}
+	}
+	votes, signed := 0, false
+	for n := uint64(1); n <= head; n++ {
+		extra, err := types.ExtractHotstuffExtra(node.chain.GetHeaderByNumber(n))
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n, err)
+		}
+		if extra.KeyVote != nil {
+			votes++
+		}
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+			t.Fatalf("failed to decode commitQC, number: %d, err: %v", n, err)
+		}
+		signers, err := signer.Signers(qc)
+		if err != nil {
+			t.Fatalf("failed to decode signers, number: %d, err: %v", n, err)
+		}
+		for _, addr := range signers {
+			if addr == joining.Address {
+				signed = true
+			}
+		}
+	}
+	if votes < Q(n) {
+		t.Errorf("expected at least %d key votes, got %d", Q(n), votes)
+	}
+	if !signed {
+		t.Errorf("registered validator never signed a commitQC in %d blocks", head)
+	}
+
+	// an auditor starting from the keys of genesis follows the registration
+	valset := makeValSet(addrs, nil, &config)
+	auditor, err := snr.NewMultiSigner(pks[0], byte(hs.MsgTypePrepareVote), suite, blsKeys[0], pubKeys[:n-1], valset)
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	if report := backend.Audit(node.chain, auditor, valset, 0, head); len(report.Issues) != 0 {
+		t.Errorf("unexpected audit issues: %v", report.Issues)
+	}
+}
@@ -208,7 +208,7 @@ func TestNewViewToWrongLeader(t *testing.T) {
 			// send to other repo
 			for _, peer := range node.broadcaster.peers {
 				if !peer.geth.IsProposer() && peer.geth.addr != node.addr {
-					peer.Send(hotstuffMsg, data)
+					peer.Send(uint64(ori.Code), data)
 				}
 			}
 
@@ -0,0 +1,188 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// TestObserverDecided checks that an observer, which neither runs consensus nor
+// receives blocks otherwise, follows the chain from the decided blocks forwarded
+// by validators
+func TestObserverDecided(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.DecideObservers = true
+
+	var (
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+
+	// the observer only holds the public BLS polynomial, to verify commit QCs
+	key, _ := crypto.GenerateKey()
+	observerConfig := config
+	observerConfig.Observer = true
+	observer := makeGethWithConfig(key, &types.BLSInfo{
+		T:          blsinfos[0].T,
+		N:          blsinfos[0].N,
+		Suite:      blsinfos[0].Suite,
+		BLSPubPoly: blsinfos[0].BLSPubPoly,
+	}, addrs, nil, &observerConfig, nil)
+	observer.miner.Stop() // observers neither mine nor run consensus
+	subscriber := observer.engine.(interface {
+		SubscribeDecided(w p2p.MsgWriter) error
+	})
+	for _, node := range nodes {
+		observer.broadcaster.Connect(node.broadcaster)
+		if err := subscriber.SubscribeDecided(observer.broadcaster.peers[node.addr].rw); err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+	}
+
+	sys.Start()
+	sys.Close(8)
+	defer observer.chain.Stop()
+
+	var (
+		head   = headNode(sys).chain.CurrentHeader().Number.Uint64()
+		number = observer.chain.CurrentHeader().Number.Uint64()
+	)
+	if head < 4 {
+		t.Fatalf("too few blocks committed, expected at least 4, got %d", head)
+	}
+	// the last block may still be on its way when the system stops
+	if number+1 < head {
+		t.Errorf("observer fell behind, expected at least %d blocks, got %d", head-1, number)
+	}
+	for n := uint64(1); n <= number; n++ {
+		if got, want := observer.chain.GetHeaderByNumber(n).Hash(), headNode(sys).chain.GetHeaderByNumber(n).Hash(); got != want {
+			t.Errorf("observer block %d mismatch, expected %v, got %v", n, want, got)
+		}
+	}
+}
+
+// TestObserverUnsubscribed checks that validators only forward decided blocks if
+// enabled
+func TestObserverUnsubscribed(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	var (
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+
+	key, _ := crypto.GenerateKey()
+	observerConfig := config
+	observerConfig.Observer = true
+	observer := makeGethWithConfig(key, blsinfos[0], addrs, nil, &observerConfig, nil)
+	observer.miner.Stop() // observers neither mine nor run consensus
+	subscriber := observer.engine.(interface {
+		SubscribeDecided(w p2p.MsgWriter) error
+	})
+	for _, node := range nodes {
+		observer.broadcaster.Connect(node.broadcaster)
+		if err := subscriber.SubscribeDecided(observer.broadcaster.peers[node.addr].rw); err != nil {
+			t.Fatalf("failed to subscribe: %v", err)
+		}
+	}
+
+	sys.Start()
+	sys.Close(4)
+	defer observer.chain.Stop()
+
+	if head := headNode(sys).chain.CurrentHeader().Number.Uint64(); head < 2 {
+		t.Fatalf("too few blocks committed, expected at least 2, got %d", head)
+	}
+	if number := observer.chain.CurrentHeader().Number.Uint64(); number != 0 {
+		t.Errorf("observer received decided blocks from validators not forwarding them, head %d", number)
+	}
+}
+
+// decidedBlockMsg is the hotstuff/1 message code of a decided block sent to observers
+const decidedBlockMsg = 0x0e
+
+// TestObserverOutOfOrder checks that an observer neither fails the validator nor
+// forgets a decided block whose parent it does not have yet, and fails a forged one
+func TestObserverOutOfOrder(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	var (
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	sys.Close(5)
+
+	chain := headNode(sys).chain
+	if head := chain.CurrentHeader().Number.Uint64(); head < 3 {
+		t.Fatalf("too few blocks committed, expected at least 3, got %d", head)
+	}
+
+	key, _ := crypto.GenerateKey()
+	observerConfig := config
+	observerConfig.Observer = true
+	observer := makeGethWithConfig(key, blsinfos[0], addrs, nil, &observerConfig, nil)
+	observer.miner.Stop() // observers neither mine nor run consensus
+	defer observer.chain.Stop()
+	handler := observer.engine.(consensus.Handler)
+
+	deliver := func(block *types.Block) error {
+		data, err := rlp.EncodeToBytes(block)
+		if err != nil {
+			t.Fatalf("failed to encode block: %v", err)
+		}
+		size, r, err := rlp.EncodeToReader(data)
+		if err != nil {
+			t.Fatalf("failed to encode message: %v", err)
+		}
+		_, err = handler.HandleMsg(nodes[0].addr, p2p.Msg{Code: decidedBlockMsg, Size: uint32(size), Payload: r})
+		return err
+	}
+
+	// the second block arrives before its parent, and is left to sync
+	if err := deliver(chain.GetBlockByNumber(2)); err != nil {
+		t.Fatalf("decided block with unknown parent failed the validator: %v", err)
+	}
+	if number := observer.chain.CurrentHeader().Number.Uint64(); number != 0 {
+		t.Fatalf("decided block with unknown parent inserted, head %d", number)
+	}
+	// once the parent is inserted, the same block is taken again
+	for n := uint64(1); n <= 2; n++ {
+		if err := deliver(chain.GetBlockByNumber(n)); err != nil {
+			t.Fatalf("failed to handle decided block %d: %v", n, err)
+		}
+		if number := observer.chain.CurrentHeader().Number.Uint64(); number != n {
+			t.Fatalf("decided block %d not inserted, head %d", n, number)
+		}
+	}
+	// a block changed after sealing no longer carries a validator seal
+	forged := chain.GetBlockByNumber(3)
+	header := forged.Header()
+	header.Time++
+	if err := deliver(forged.WithSeal(header)); err == nil {
+		t.Errorf("forged decided block accepted")
+	}
+	if number := observer.chain.CurrentHeader().Number.Uint64(); number != 2 {
+		t.Errorf("forged decided block inserted, head %d", number)
+	}
+}
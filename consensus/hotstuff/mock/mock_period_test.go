@@ -0,0 +1,57 @@
+package mock
+
+import (
+	"testing"
+	"time"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// TestPeriodMilliseconds checks that sub-second block periods produce several blocks
+// per second, each at least a period after its parent
+func TestPeriodMilliseconds(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriodMs = 200
+
+	sys := makeSystemWithConfig(4, &config)
+	sys.Start()
+	sys.Close(5)
+
+	var (
+		chain  = headNode(sys).chain
+		head   = chain.CurrentHeader().Number.Uint64()
+		period = config.Period()
+	)
+	if head < 10 {
+		t.Fatalf("too few blocks committed, expected at least 10, got %d", head)
+	}
+	for n := uint64(2); n <= head; n++ {
+		parent, header := chain.GetHeaderByNumber(n-1), chain.GetHeaderByNumber(n)
+		if diff := types.HotstuffHeaderTime(header).Sub(types.HotstuffHeaderTime(parent)); diff < period {
+			t.Errorf("block period not respected, number: %d, expected at least %v, got %v", n, period, diff)
+		}
+	}
+}
+
+// TestPeriodMillisecondsEncoding checks that the milliseconds survive the header
+// encoding, and that whole second headers keep their encoding
+func TestPeriodMillisecondsEncoding(t *testing.T) {
+	header := &types.Header{Time: 100}
+	if err := types.HotstuffHeaderFillWithValidators(header, nil); err != nil {
+		t.Fatalf("failed to fill header: %v", err)
+	}
+	legacy := len(header.Extra)
+	if err := header.SetHotstuffMillis(0); err != nil {
+		t.Fatalf("failed to set milliseconds: %v", err)
+	}
+	if len(header.Extra) != legacy {
+		t.Errorf("whole second extra changed, expected %d bytes, got %d", legacy, len(header.Extra))
+	}
+	if err := header.SetHotstuffMillis(250); err != nil {
+		t.Fatalf("failed to set milliseconds: %v", err)
+	}
+	if got, want := types.HotstuffHeaderTime(header), time.Unix(100, 250*int64(time.Millisecond)); !got.Equal(want) {
+		t.Errorf("header time mismatch, expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,63 @@
+package mock
+
+import (
+	"sync/atomic"
+	"testing"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+)
+
+// TestPipelinedBuild checks that the next proposers seal the blocks they built on
+// the locked blocks, and that the chains agree on the blocks and their transactions
+func TestPipelinedBuild(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.PipelinedBuild = true
+
+	var (
+		keys, alloc          = newWorkloadAccounts(4)
+		pool                 = newTxPool()
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+	)
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &config, alloc)
+		nodes[i].miner.txpool = pool
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	feeder := newTxFeeder(nodes[0].chain.Config(), keys, 50, pool)
+	feeder.Start()
+	sys.Start()
+	sys.Close(10)
+	feeder.Stop()
+
+	var (
+		head = nodes[0].chain.CurrentHeader().Number.Uint64()
+		hits int32
+		txs  int
+	)
+	for _, node := range nodes {
+		if n := node.chain.CurrentHeader().Number.Uint64(); n < head {
+			head = n
+		}
+		hits += atomic.LoadInt32(&node.miner.speculativeHits)
+	}
+	if head < 5 {
+		t.Fatalf("too few blocks committed, expected at least 5, got %d", head)
+	}
+	if hits == 0 {
+		t.Errorf("no block built on a locked block was sealed")
+	}
+	for n := uint64(1); n <= head; n++ {
+		block := nodes[0].chain.GetBlockByNumber(n)
+		for _, node := range nodes[1:] {
+			if hash := node.chain.GetHeaderByNumber(n).Hash(); hash != block.Hash() {
+				t.Fatalf("chains diverged, number: %d, expected %v, got %v", n, block.Hash(), hash)
+			}
+		}
+		txs += len(block.Transactions())
+	}
+	if txs == 0 {
+		t.Errorf("no transactions committed")
+	}
+}
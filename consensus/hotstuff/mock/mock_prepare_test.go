@@ -922,3 +922,29 @@ func TestPrepareVoteFaultyPayloadBad(t *testing.T) {
 		t.Fail()
 	}
 }
+
+// TestPrepareScheduledProposal checks that the leader still respects the block
+// period once proposals are scheduled at the block timestamp instead of delayed
+// inside the event loop.
+func TestPrepareScheduledProposal(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 3
+
+	sys := makeSystemWithConfig(4, &config)
+	sys.Start()
+	sys.Close(30)
+
+	var (
+		chain = sys.nodes[0].chain
+		head  = chain.CurrentHeader().Number.Uint64()
+	)
+	if head < 4 {
+		t.Fatalf("too few blocks committed, expected at least 4, got %d", head)
+	}
+	for n := uint64(2); n <= head; n++ {
+		parent, header := chain.GetHeaderByNumber(n-1), chain.GetHeaderByNumber(n)
+		if header.Time < parent.Time+config.BlockPeriod {
+			t.Errorf("block period not respected, number: %d, parent time: %d, time: %d", n, parent.Time, header.Time)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package mock
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+type handshakeResult struct {
+	addr common.Address
+	err  error
+}
+
+func handshake(a, b consensus.Handshaker, aID, bID, aSeen, bSeen enode.ID) (handshakeResult, handshakeResult) {
+	rwA, rwB := p2p.MsgPipe()
+	defer rwA.Close()
+	defer rwB.Close()
+
+	resA, resB := make(chan handshakeResult, 1), make(chan handshakeResult, 1)
+	go func() {
+		addr, err := a.Handshake(rwA, aID, aSeen)
+		resA <- handshakeResult{addr, err}
+	}()
+	go func() {
+		addr, err := b.Handshake(rwB, bID, bSeen)
+		resB <- handshakeResult{addr, err}
+	}()
+	return <-resA, <-resB
+}
+
+func TestHotstuffHandshake(t *testing.T) {
+	sys := makeSystem(4)
+	a := sys.nodes[0].engine.(consensus.Handshaker)
+	b := sys.nodes[1].engine.(consensus.Handshaker)
+	idA, idB := enode.ID{0x01}, enode.ID{0x02}
+
+	resA, resB := handshake(a, b, idA, idB, idB, idA)
+	if resA.err != nil || resB.err != nil {
+		t.Fatalf("handshake failed: %v, %v", resA.err, resB.err)
+	}
+	if resA.addr != sys.nodes[1].addr {
+		t.Errorf("remote address mismatch, expected %v, got %v", sys.nodes[1].addr, resA.addr)
+	}
+	if resB.addr != sys.nodes[0].addr {
+		t.Errorf("remote address mismatch, expected %v, got %v", sys.nodes[0].addr, resB.addr)
+	}
+
+	// a proof made for another connection must be rejected
+	resA, _ = handshake(a, b, idA, enode.ID{0x03}, idB, idA)
+	if resA.err == nil {
+		t.Errorf("handshake with a proof bound to another node ID succeeded")
+	}
+}
+
+func TestHotstuffPriority(t *testing.T) {
+	sys := makeSystem(4)
+	p := sys.nodes[0].engine.(consensus.Prioritizer)
+	for code := uint64(hs.MsgTypeNewView); code <= uint64(hs.MsgTypeDecide); code++ {
+		if high := p.HighPriority(code); high == (code == uint64(hs.MsgTypePrepare)) {
+			t.Errorf("unexpected priority for message code %d, high: %v", code, high)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// remoteKeys serves the keys of a signer as a remote signer would, optionally
+// hanging until the call is canceled
+type remoteKeys struct {
+	signer hs.Signer
+	hang   bool
+}
+
+func (k *remoteKeys) wait(ctx context.Context) error {
+	if k.hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (k *remoteKeys) Address(ctx context.Context) (common.Address, error) {
+	return k.signer.Address(), nil
+}
+
+func (k *remoteKeys) Sign(ctx context.Context, hash common.Hash) ([]byte, error) {
+	if err := k.wait(ctx); err != nil {
+		return nil, err
+	}
+	return k.signer.Sign(hash)
+}
+
+func (k *remoteKeys) BLSSign(ctx context.Context, data []byte) ([]byte, error) {
+	if err := k.wait(ctx); err != nil {
+		return nil, err
+	}
+	return k.signer.BLSSign(data)
+}
+
+// newRemoteSigner creates a remote signer on top of a signer holding no validator
+// key, only the BLS public keys
+func newRemoteSigner(t *testing.T, keys *remoteKeys, blsInfo *types.BLSInfo, timeout time.Duration) *snr.RemoteSigner {
+	nodeKey, _ := crypto.GenerateKey()
+	local := snr.NewSigner(nodeKey, byte(hs.MsgTypePrepareVote), &types.BLSInfo{
+		T:          blsInfo.T,
+		N:          blsInfo.N,
+		Suite:      blsInfo.Suite,
+		BLSPubPoly: blsInfo.BLSPubPoly,
+	})
+	signer, err := snr.NewRemoteSigner(local, keys, timeout)
+	if err != nil {
+		t.Fatalf("failed to create remote signer: %v", err)
+	}
+	return signer
+}
+
+func TestRemoteSignerCommit(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	pks, blsinfos, addrs := newAccountLists(4)
+	nodes := make([]*Geth, len(pks))
+	for i := range nodes {
+		keys := &remoteKeys{signer: snr.NewSigner(pks[i], byte(hs.MsgTypePrepareVote), blsinfos[i])}
+		signer := newRemoteSigner(t, keys, blsinfos[i], time.Second)
+		if signer.Address() != addrs[i] {
+			t.Fatalf("remote signer address mismatch, expected %s, got %s", addrs[i].Hex(), signer.Address().Hex())
+		}
+		nodes[i] = makeGethWithSigner(signer, addrs, nil, &config, nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+
+	sys.Start()
+	sys.Close(10)
+
+	var (
+		chain = sys.nodes[0].chain
+		head  = chain.CurrentHeader().Number.Uint64()
+	)
+	if head < 4 {
+		t.Fatalf("too few blocks committed, expected at least 4, got %d", head)
+	}
+	for n := uint64(1); n <= head; n++ {
+		header := chain.GetHeaderByNumber(n)
+		if err := sys.nodes[0].signer.VerifyHeader(header, makeValSet(addrs, nil, &config), true); err != nil {
+			t.Errorf("invalid header, number: %d, err: %v", n, err)
+		}
+	}
+}
+
+func TestRemoteSignerTimeout(t *testing.T) {
+	pks, blsinfos, _ := newAccountLists(4)
+	keys := &remoteKeys{signer: snr.NewSigner(pks[0], byte(hs.MsgTypePrepareVote), blsinfos[0]), hang: true}
+	signer := newRemoteSigner(t, keys, blsinfos[0], 100*time.Millisecond)
+
+	// a hanging call fails once its deadline passes
+	start := time.Now()
+	if _, err := signer.Sign(common.HexToHash("0x01")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if _, err := signer.BLSSign([]byte("data")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("remote calls not bounded by the timeout, took %v", elapsed)
+	}
+
+	// closing cancels a pending call and fails the next ones
+	done := make(chan error, 1)
+	signer = newRemoteSigner(t, keys, blsinfos[0], 0)
+	go func() {
+		_, err := signer.BLSSign([]byte("data"))
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	signer.Close()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected canceled call, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("pending call not canceled")
+	}
+	if _, err := signer.Sign(common.HexToHash("0x01")); err == nil {
+		t.Errorf("expected closed signer to fail")
+	}
+
+	// a signature of another key is rejected
+	other, _ := crypto.GenerateKey()
+	keys = &remoteKeys{signer: snr.NewSigner(pks[0], byte(hs.MsgTypePrepareVote), blsinfos[0])}
+	signer = newRemoteSigner(t, keys, blsinfos[0], time.Second)
+	keys.signer = snr.NewSigner(other, byte(hs.MsgTypePrepareVote), blsinfos[0])
+	if _, err := signer.Sign(common.HexToHash("0x01")); err == nil {
+		t.Errorf("expected signature of another key to be rejected")
+	}
+}
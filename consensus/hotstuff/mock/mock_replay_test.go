@@ -0,0 +1,161 @@
+package mock
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/recorder"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/replay"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+)
+
+// TestReplayRecording checks that replaying the recording of every node commits
+// the blocks the node committed, in the same order
+func TestReplayRecording(t *testing.T) {
+	var (
+		dir                  = t.TempDir()
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+		configs              = make([]hs.Config, len(pks))
+	)
+	for i := range nodes {
+		configs[i] = *hs.DefaultBasicConfig
+		configs[i].BlockPeriod = 1
+		configs[i].RecordFile = filepath.Join(dir, fmt.Sprintf("node%d.rec", i))
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &configs[i], nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	sys.Close(6)
+
+	for i, node := range nodes {
+		checkReplay(t, i, node, &configs[i], makeValSet(addrs, nil, &configs[i]))
+	}
+}
+
+// TestReplayRecordingTree checks that recordings of the Tree topology replay, although
+// the votes aggregated by internal nodes reach the leader without a signature of
+// their own
+func TestReplayRecordingTree(t *testing.T) {
+	var (
+		dir               = t.TempDir()
+		pks, _, addrs     = newAccountLists(10)
+		suite, keys, pubs = GenerateMultiSigKeys(addrs)
+		nodes             = make([]*Geth, len(pks))
+		configs           = make([]hs.Config, len(pks))
+	)
+	for i := range nodes {
+		configs[i] = *hs.DefaultBasicConfig
+		configs[i].BlockPeriod = 1
+		configs[i].Topology = hs.Tree
+		configs[i].TreeFanout = 3
+		configs[i].SignatureScheme = hs.MultiBLS
+		configs[i].RecordFile = filepath.Join(dir, fmt.Sprintf("node%d.rec", i))
+
+		valset := makeValSet(addrs, nil, &configs[i])
+		signer, err := snr.NewMultiSigner(pks[i], byte(hs.MsgTypePrepareVote), suite, keys[i], pubs, valset)
+		if err != nil {
+			t.Fatalf("node %d: failed to create signer: %v", i, err)
+		}
+		nodes[i] = makeGethWithValSet(signer, valset, addrs, &configs[i], nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	sys.Close(8)
+
+	for i, node := range nodes {
+		checkReplay(t, i, node, &configs[i], makeValSet(addrs, nil, &configs[i]))
+	}
+}
+
+// checkReplay replays the recording of a node, which must send messages and commit
+// the blocks the node committed, in the same order and without skipping any
+func checkReplay(t *testing.T, i int, node *Geth, config *hs.Config, valset hs.ValidatorSet) {
+	records, err := recorder.ReadFile(config.RecordFile)
+	if err != nil {
+		t.Fatalf("node %d: failed to read recording: %v", i, err)
+	}
+	replayer, err := replay.New(records, config, valset, node.signer)
+	if err != nil {
+		t.Fatalf("node %d: failed to create replayer: %v", i, err)
+	}
+	sent := 0
+	for _, rec := range records {
+		msgs, _ := replayer.Step(rec)
+		sent += len(msgs)
+	}
+	if sent == 0 {
+		t.Errorf("node %d: replay sent no messages", i)
+	}
+
+	committed := replayer.Committed()
+	if head := node.chain.CurrentHeader().Number.Uint64(); uint64(len(committed))+1 < head {
+		t.Errorf("node %d: replay committed %d blocks, node reached %d", i, len(committed), head)
+	}
+	for j, block := range committed {
+		if j > 0 && block.NumberU64() != committed[j-1].NumberU64()+1 {
+			t.Errorf("node %d: replay skipped from block %d to %d", i, committed[j-1].NumberU64(), block.NumberU64())
+		}
+		if header := node.chain.GetHeaderByNumber(block.NumberU64()); header == nil || header.Hash() != block.Hash() {
+			t.Errorf("node %d: replayed block %d mismatch, got %v", i, block.NumberU64(), block.Hash())
+		}
+	}
+}
+
+// TestRecorderRotation checks that a recording rotates once it exceeds its size,
+// keeping a bounded number of older files, each of which starts at the head reached
+// when it was opened
+func TestRecorderRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "node.rec")
+	rec, err := recorder.New(path, 1)
+	if err != nil {
+		t.Fatalf("failed to open recording: %v", err)
+	}
+	var (
+		local = common.BytesToAddress([]byte{0xff})
+		data  = make([]byte, 100*1024)
+		heads = make(map[string]bool)
+	)
+	for i := 0; i < 50; i++ {
+		if i%5 == 0 {
+			head := []byte(fmt.Sprintf("head %d", i))
+			heads[string(head)] = true
+			if i == 0 {
+				rec.Record(recorder.Start, local, head)
+			} else {
+				rec.Record(recorder.FinalCommitted, common.Address{}, head)
+			}
+		}
+		rec.Record(recorder.Message, common.BytesToAddress([]byte{byte(i)}), data)
+	}
+	rec.Close()
+
+	total := 0
+	for _, name := range []string{path + ".3", path + ".2", path + ".1", path} {
+		records, err := recorder.ReadFile(name)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if len(records) == 0 || records[0].Kind != recorder.Start {
+			t.Fatalf("%s does not start with a Start record", name)
+		}
+		if records[0].Src != local || !heads[string(records[0].Data)] {
+			t.Errorf("%s starts at an unknown head %q from %v", name, records[0].Data, records[0].Src)
+		}
+		for _, r := range records {
+			if r.Kind == recorder.Message {
+				total++
+			}
+		}
+	}
+	if total >= 50 || total < 30 {
+		t.Errorf("unexpected number of records kept, got %d", total)
+	}
+	if _, err := recorder.ReadFile(path + ".4"); err == nil {
+		t.Errorf("more rotated files kept than expected")
+	}
+}
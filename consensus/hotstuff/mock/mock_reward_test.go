@@ -0,0 +1,138 @@
+package mock
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// runRewardSystem runs sys with the given block reward settings in the genesis of
+// every node, and returns the committed chain of the first node with its balances
+func runRewardSystem(t *testing.T, sys *System, reward int64, mode string, beneficiaries []common.Address) (*Geth, uint64, func(common.Address) *big.Int) {
+	for _, node := range sys.nodes {
+		config := node.chain.Config().HotStuff
+		config.BlockReward = math.NewHexOrDecimal256(reward)
+		config.BeneficiaryMode = mode
+		config.Beneficiaries = beneficiaries
+	}
+	sys.Start()
+	sys.Close(10)
+
+	node := sys.nodes[0]
+	head := node.chain.CurrentBlock()
+	if head.NumberU64() < 4 {
+		t.Fatalf("too few blocks committed, expected at least 4, got %d", head.NumberU64())
+	}
+	state, _, err := node.chain.StateAt(head.Root())
+	if err != nil {
+		t.Fatalf("failed to read head state: %v", err)
+	}
+	return node, head.NumberU64(), state.GetBalance
+}
+
+func TestBlockRewardProposer(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	sys := makeSystemWithConfig(4, &config)
+
+	node, head, balance := runRewardSystem(t, sys, 10, "", nil)
+
+	proposed := make(map[common.Address]int64)
+	for n := uint64(1); n <= head; n++ {
+		proposed[node.chain.GetHeaderByNumber(n).Coinbase]++
+	}
+	for _, val := range sys.nodes {
+		if want := big.NewInt(10 * proposed[val.addr]); balance(val.addr).Cmp(want) != 0 {
+			t.Errorf("proposer balance mismatch, have %v, want %v", balance(val.addr), want)
+		}
+	}
+}
+
+func TestBlockRewardFixed(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	sys := makeSystemWithConfig(4, &config)
+
+	// the remainder of the division is not minted
+	beneficiaries := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+	_, head, balance := runRewardSystem(t, sys, 11, params.HotStuffBeneficiaryFixed, beneficiaries)
+
+	for _, addr := range beneficiaries {
+		if want := big.NewInt(5 * int64(head)); balance(addr).Cmp(want) != 0 {
+			t.Errorf("beneficiary balance mismatch, have %v, want %v", balance(addr), want)
+		}
+	}
+	for _, val := range sys.nodes {
+		if balance(val.addr).Sign() != 0 {
+			t.Errorf("validator credited in the fixed beneficiary mode, balance: %v", balance(val.addr))
+		}
+	}
+}
+
+func TestBlockRewardValidators(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	// one node stays silent, so it never signs a commit QC
+	sys := makeMultiSigSystem(4, &config)
+	silent := sys.nodes[3]
+	silent.setHook(func(node *Geth, data []byte) ([]byte, bool) {
+		return data, false
+	})
+
+	node, head, balance := runRewardSystem(t, sys, 12, params.HotStuffBeneficiaryValidators, nil)
+
+	// every block shares the reward among the signers of the commit QC of its parent,
+	// and the first block, whose parent has no QC, rewards nobody
+	signer := node.signer.(*snr.MultiSigner)
+	want := make(map[common.Address]int64)
+	for n := uint64(2); n <= head; n++ {
+		extra, err := types.ExtractHotstuffExtra(node.chain.GetHeaderByNumber(n - 1))
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n-1, err)
+		}
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+			t.Fatalf("failed to decode commitQC, number: %d, err: %v", n-1, err)
+		}
+		signers, err := signer.Signers(qc)
+		if err != nil {
+			t.Fatalf("failed to decode signers, number: %d, err: %v", n-1, err)
+		}
+		for _, addr := range signers {
+			want[addr] += 12 / int64(len(signers))
+		}
+	}
+	for _, val := range sys.nodes {
+		if balance(val.addr).Cmp(big.NewInt(want[val.addr])) != 0 {
+			t.Errorf("validator balance mismatch, have %v, want %v", balance(val.addr), want[val.addr])
+		}
+	}
+	if balance(silent.addr).Sign() != 0 {
+		t.Errorf("silent validator rewarded, balance: %v", balance(silent.addr))
+	}
+}
+
+func TestBlockRewardValidatorsThreshold(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	sys := makeSystemWithConfig(4, &config)
+
+	_, head, balance := runRewardSystem(t, sys, 12, params.HotStuffBeneficiaryValidators, nil)
+
+	// the threshold signature hides the signers, so every block after the first one,
+	// whose parent has no QC, shares the reward among the validators of its parent
+	want := big.NewInt(int64(12 / len(sys.nodes) * (int(head) - 1)))
+	for _, val := range sys.nodes {
+		if balance(val.addr).Cmp(want) != 0 {
+			t.Errorf("validator balance mismatch, node: %v, have %v, want %v", val.addr, balance(val.addr), want)
+		}
+	}
+}
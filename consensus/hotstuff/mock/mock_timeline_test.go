@@ -0,0 +1,89 @@
+package mock
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/timeline"
+)
+
+// TestTimeline checks that every node keeps the timeline of the views it decided,
+// and writes their events to its timeline file
+func TestTimeline(t *testing.T) {
+	var (
+		dir                  = t.TempDir()
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+		configs              = make([]hs.Config, len(pks))
+	)
+	for i := range nodes {
+		configs[i] = *hs.DefaultBasicConfig
+		configs[i].BlockPeriod = 1
+		configs[i].TimelineFile = filepath.Join(dir, fmt.Sprintf("node%d.jsonl", i))
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, &configs[i], nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	sys.Close(5)
+
+	node := headNode(sys)
+	api := node.engine.APIs(node.chain)[1].Service.(*backend.DebugAPI)
+	views := api.HotstuffTimeline(1)
+	if len(views) == 0 {
+		t.Fatalf("no timeline of height 1")
+	}
+	view := views[len(views)-1]
+	if view.Node != node.addr || view.Height != 1 {
+		t.Errorf("timeline of %v at height %d, want %v at height 1", view.Node, view.Height, node.addr)
+	}
+	kinds := make(map[timeline.Kind]int)
+	for i, ev := range view.Events {
+		if i > 0 && ev.Time.Before(view.Events[i-1].Time) {
+			t.Errorf("event %d at %v before the previous one", i, ev.Time)
+		}
+		if ev.Kind == timeline.Received && ev.Peer == nil {
+			t.Errorf("received %s without a peer", ev.Code)
+		}
+		kinds[ev.Kind]++
+	}
+	if view.Events[0].Kind != timeline.Start {
+		t.Errorf("timeline starts with %s, want %s", view.Events[0].Kind, timeline.Start)
+	}
+	for _, kind := range []timeline.Kind{timeline.Sent, timeline.Received, timeline.Sealed, timeline.Committed} {
+		if kinds[kind] == 0 {
+			t.Errorf("no %s event at height 1", kind)
+		}
+	}
+
+	// the files of all nodes merge into the events of each view
+	committed := make(map[uint64]int)
+	for i := range nodes {
+		file, err := os.Open(configs[i].TimelineFile)
+		if err != nil {
+			t.Fatalf("node %d: failed to open timeline: %v", i, err)
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			var line struct {
+				Height uint64 `json:"height"`
+				timeline.Event
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+				t.Fatalf("node %d: invalid timeline line %q: %v", i, scanner.Text(), err)
+			}
+			if line.Kind == timeline.Committed {
+				committed[line.Height]++
+			}
+		}
+		file.Close()
+	}
+	if committed[1] < 3 {
+		t.Errorf("height 1 committed by %d nodes in the timeline files, want a quorum", committed[1])
+	}
+}
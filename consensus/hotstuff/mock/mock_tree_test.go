@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"testing"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func commitRounds(t *testing.T, node *Geth) (head uint64, timeouts int) {
+	chain := node.chain
+	head = chain.CurrentHeader().Number.Uint64()
+	for n := uint64(1); n <= head; n++ {
+		extra, err := types.ExtractHotstuffExtra(chain.GetHeaderByNumber(n))
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n, err)
+		}
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+			t.Fatalf("failed to decode commitQC, number: %d, err: %v", n, err)
+		}
+		if qc.View.Round.Uint64() > 0 {
+			timeouts++
+		}
+	}
+	return head, timeouts
+}
+
+func TestTreeTopologyCommit(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.Topology = hs.Tree
+	config.TreeFanout = 3
+
+	// a tree of depth 2: the leader, 3 internal nodes and 6 leaves
+	sys := makeSystemWithConfig(10, &config)
+	sys.Start()
+	sys.Close(20)
+
+	head, timeouts := commitRounds(t, sys.nodes[0])
+	if head < 8 {
+		t.Fatalf("too few blocks committed, expected at least 8, got %d", head)
+	}
+	// every block commits in the first round, i.e. over the tree
+	if timeouts != 0 {
+		t.Errorf("expected no timed out height, got %d in %d blocks", timeouts, head)
+	}
+}
+
+func TestTreeTopologyFallback(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.RequestTimeout = 2000
+	config.Topology = hs.Tree
+	config.TreeFanout = 3
+
+	// the last validator is offline, which cuts off its subtree from quorum
+	// whenever it is an internal node of the tree
+	sys := makeSystemWithConfig(10, &config)
+	sys.nodes = sys.nodes[:9]
+
+	sys.Start()
+	sys.Close(40)
+
+	head, timeouts := commitRounds(t, sys.nodes[0])
+	if head < 8 {
+		t.Fatalf("too few blocks committed, expected at least 8, got %d", head)
+	}
+	if timeouts == 0 {
+		t.Errorf("expected heights committed after falling back to the star topology, got none in %d blocks", head)
+	}
+}
+
+func TestTreeTopologyMultiSig(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.Topology = hs.Tree
+	config.TreeFanout = 3
+
+	// internal nodes aggregate the votes of their subtree into one share, which the
+	// leader aggregates again into the QC
+	sys := makeMultiSigSystem(10, &config)
+	sys.Start()
+	sys.Close(20)
+
+	var (
+		node   = sys.nodes[0]
+		signer = node.signer.(*snr.MultiSigner)
+	)
+	head, timeouts := commitRounds(t, node)
+	if head < 8 {
+		t.Fatalf("too few blocks committed, expected at least 8, got %d", head)
+	}
+	if timeouts != 0 {
+		t.Errorf("expected no timed out height, got %d in %d blocks", timeouts, head)
+	}
+	for n := uint64(1); n <= head; n++ {
+		extra, err := types.ExtractHotstuffExtra(node.chain.GetHeaderByNumber(n))
+		if err != nil {
+			t.Fatalf("failed to extract extra, number: %d, err: %v", n, err)
+		}
+		var qc *hs.QuorumCert
+		if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+			t.Fatalf("failed to decode commitQC, number: %d, err: %v", n, err)
+		}
+		if err := signer.AuthQC(qc); err != nil {
+			t.Errorf("invalid commitQC, number: %d, err: %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package mock
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+)
+
+// countingSigner counts the pairing checks of every QC
+type countingSigner struct {
+	hs.Signer
+
+	mu    sync.Mutex
+	auths map[common.Hash]int
+}
+
+func (s *countingSigner) AuthQC(qc *hs.QuorumCert) error {
+	s.mu.Lock()
+	s.auths[hs.RLPHash(qc)]++
+	s.mu.Unlock()
+	return s.Signer.AuthQC(qc)
+}
+
+// TestVerifyQCOnce checks that every node checks the signature of a QC once, although
+// the same QC arrives in the NewView messages of all validators and in the proposal
+func TestVerifyQCOnce(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+	config.VerifyWorkers = 4
+
+	var (
+		pks, blsinfos, addrs = newAccountLists(4)
+		nodes                = make([]*Geth, len(pks))
+		signers              = make([]*countingSigner, len(pks))
+	)
+	for i := range nodes {
+		signers[i] = &countingSigner{
+			Signer: snr.NewSigner(pks[i], byte(hs.MsgTypePrepareVote), blsinfos[i]),
+			auths:  make(map[common.Hash]int),
+		}
+		nodes[i] = makeGethWithSigner(signers[i], addrs, nil, &config, nil)
+	}
+	sys := &System{nodes: nodes, exit: make(chan struct{})}
+	sys.Start()
+	sys.Close(5)
+
+	if head := headNode(sys).chain.CurrentHeader().Number.Uint64(); head < 3 {
+		t.Fatalf("too few blocks committed, expected at least 3, got %d", head)
+	}
+	for i, signer := range signers {
+		signer.mu.Lock()
+		if len(signer.auths) == 0 {
+			t.Errorf("node %d checked no QC", i)
+		}
+		for hash, n := range signer.auths {
+			if n > 1 {
+				t.Errorf("node %d checked QC %x %d times", i, hash, n)
+			}
+		}
+		signer.mu.Unlock()
+	}
+}
@@ -0,0 +1,85 @@
+package mock
+
+import (
+	"testing"
+	"time"
+
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+)
+
+func TestWeightedLeaderSchedule(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	// total weight 6, F = 1 and Q = 5: the heavy node alone cannot commit,
+	// but it takes part in every quorum and leads every other view
+	weights := []int{3, 1, 1, 1}
+	sys := makeWeightedSystem(weights, &config)
+	heavy := sys.nodes[0]
+
+	sys.Start()
+	sys.Close(20)
+
+	var (
+		chain     = sys.nodes[1].chain
+		head      = chain.CurrentHeader().Number.Uint64()
+		proposals = 0
+	)
+	for n := uint64(1); n <= head; n++ {
+		if chain.GetHeaderByNumber(n).Coinbase == heavy.addr {
+			proposals++
+		}
+	}
+
+	if head < 6 {
+		t.Fatalf("too few blocks committed, expected at least 6, got %d", head)
+	}
+	// the weighted schedule gives the heavy node 3 views out of 6
+	if min, max := int(head)/2-1, int(head)/2+1; proposals < min || proposals > max {
+		t.Errorf("heavy node proposal count mismatch, expected %d-%d, got %d of %d blocks", min, max, proposals, head)
+	}
+}
+
+func TestWeightedQuorumWithoutHeavy(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	// the light nodes hold a weight of 3, below Q = 5
+	weights := []int{3, 1, 1, 1}
+	sys := makeWeightedSystem(weights, &config)
+	sys.nodes[0].setHook(func(node *Geth, data []byte) ([]byte, bool) {
+		return data, false
+	})
+
+	sys.Start()
+	time.Sleep(10 * time.Second)
+	sys.Stop()
+	time.Sleep(time.Second)
+
+	for _, node := range sys.nodes[1:] {
+		if head := node.chain.CurrentHeader().Number.Uint64(); head != 0 {
+			t.Errorf("no block should be committed without the heavy node, got head %d", head)
+		}
+	}
+}
+
+func TestWeightedQCParticipants(t *testing.T) {
+	config := *hs.DefaultBasicConfig
+	config.BlockPeriod = 1
+
+	// total weight 6 and Q = 5: every multi-signature QC holds the heavy node, so
+	// the voting power of its signers is at least 5 for 2 to 4 signers
+	weights := []int{3, 1, 1, 1}
+	sys := makeWeightedMultiSigSystem(weights, &config)
+
+	sys.Start()
+	time.Sleep(10 * time.Second)
+
+	for _, node := range sys.nodes {
+		status := node.engine.(interface{ Status() *hs.Status }).Status()
+		if status.QCParticipants < 5 || status.QCParticipants > 6 {
+			t.Errorf("QC voting power mismatch, node: %v, expected 5-6, got %d", node.addr, status.QCParticipants)
+		}
+	}
+	sys.Stop()
+}
@@ -82,7 +82,7 @@ func (b *broadcaster) add(remote common.Address, rw *p2p.MsgPipeRW) {
 				log.Error("Failed to handle message", "err", err)
 				return
 			}
-			if msg.Code == eth.NewBlockMsg {
+			if msg.Code == newBlockMsg {
 				b.geth.handleBlock(msg)
 			}
 		}
@@ -96,7 +96,7 @@ type MockPeer struct {
 }
 
 func (p *MockPeer) SendNewBlock(block *types.Block, td *big.Int) error {
-	return p2p.Send(p.rw, eth.NewBlockMsg, &eth.NewBlockPacket{
+	return p2p.Send(p.rw, newBlockMsg, &eth.NewBlockPacket{
 		Block: block,
 		TD:    td,
 	})
@@ -105,7 +105,7 @@ func (p *MockPeer) SendNewBlock(block *types.Block, td *big.Int) error {
 func (p *MockPeer) Send(msgcode uint64, data interface{}) error {
 	send := true
 
-	if p.geth.hook != nil && msgcode == hotstuffMsg {
+	if p.geth.hook != nil && isConsensusMsg(msgcode) {
 		if raw, ok := data.([]byte); !ok {
 			panic("Send hotstuff message data convert failed")
 		} else {
@@ -125,7 +125,7 @@ func (p *MockPeer) Send(msgcode uint64, data interface{}) error {
 func (p *MockPeer) SendConsensus(msgcode uint64, data interface{}) error {
 	send := true
 
-	if p.geth.hook != nil && msgcode == hotstuffMsg {
+	if p.geth.hook != nil && isConsensusMsg(msgcode) {
 		if raw, ok := data.([]byte); !ok {
 			panic("Send hotstuff message data convert failed")
 		} else {
@@ -137,6 +137,9 @@ func (p *MockPeer) SendConsensus(msgcode uint64, data interface{}) error {
 			log.Error("Failed to send msg", "local", p.local, "remote", p.remote, "err", err)
 			return err
 		}
+		if raw, ok := data.([]byte); ok {
+			p.geth.stats.Record(len(raw))
+		}
 	}
 
 	return nil
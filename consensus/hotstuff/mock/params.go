@@ -17,16 +17,23 @@ const (
 	EpochStart = uint64(0)
 	EpochEnd   = uint64(10000000000)
 
-	hotstuffMsg = 0x11
+	// newBlockMsg carries eth.NewBlockMsg past the hotstuff/1 message codes, as the
+	// mock network multiplexes both protocols on a single pipe
+	newBlockMsg = 0x10
 )
 
+// isConsensusMsg reports whether a hotstuff/1 message code carries a consensus message
+func isConsensusMsg(msgcode uint64) bool {
+	return msgcode >= uint64(hs.MsgTypeNewView) && msgcode <= uint64(hs.MsgTypeDecide) || msgcode == uint64(hs.MsgTypeSuspect)
+}
+
 func init() {
 	glogger := log.NewGlogHandler(log.StreamHandler(os.Stderr, log.TerminalFormat(false)))
 	glogger.Verbosity(log.LvlTrace)
 	log.Root().SetHandler(glogger)
 }
 
-func makeGenesis(vals []common.Address) *core.Genesis {
+func makeGenesis(vals []common.Address, alloc core.GenesisAlloc) *core.Genesis {
 	genesis := &core.Genesis{
 		Config: &params.ChainConfig{
 			ChainID:             big.NewInt(60801),
@@ -48,6 +55,7 @@ func makeGenesis(vals []common.Address) *core.Genesis {
 		Mixhash:    common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
 		ParentHash: common.HexToHash("0x0000000000000000000000000000000000000000000000000000000000000000"),
 		Timestamp:  0,
+		Alloc:      alloc,
 	}
 
 	valset := validator.NewSet(vals, hs.RoundRobin)
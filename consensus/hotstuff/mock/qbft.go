@@ -0,0 +1,301 @@
+package mock
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/istanbul"
+	istanbulBackend "github.com/ethereum/go-ethereum/consensus/istanbul/backend"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/mps"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// qbftNode is the QBFT counterpart of Geth, used to compare HotStuff against
+// QBFT on the same in-process network and workload.
+type qbftNode struct {
+	addr        common.Address
+	chain       *core.BlockChain
+	engine      *istanbulBackend.Backend
+	broadcaster *qbftBroadcaster
+	txpool      *txPool
+	stats       *netStats
+
+	headCh  chan core.ChainHeadEvent
+	headSub event.Subscription
+
+	pendingMu sync.Mutex
+	pending   map[common.Hash]*qbftTask
+	stopSeal  chan struct{}
+
+	exit chan struct{}
+}
+
+func makeQBFTNode(privateKey *ecdsa.PrivateKey, genesis *core.Genesis, blockPeriod, requestTimeout uint64) *qbftNode {
+	config := *istanbul.DefaultConfig
+	config.ProposerPolicy = istanbul.NewRoundRobinProposerPolicy()
+	config.BlockPeriod = blockPeriod
+	config.RequestTimeout = requestTimeout
+	config.TestQBFTBlock = common.Big0
+
+	db := rawdb.NewMemoryDatabase()
+	engine := istanbulBackend.New(&config, privateKey, db)
+	genesis.MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, genesis.Config, engine, vm.Config{}, nil, nil, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	node := &qbftNode{
+		addr:    engine.Address(),
+		chain:   chain,
+		engine:  engine,
+		headCh:  make(chan core.ChainHeadEvent, 1),
+		pending: make(map[common.Hash]*qbftTask),
+		exit:    make(chan struct{}),
+	}
+	node.broadcaster = &qbftBroadcaster{node: node, peers: make(map[common.Address]*qbftPeer)}
+	engine.SetBroadcaster(node.broadcaster)
+	node.headSub = chain.SubscribeChainHeadEvent(node.headCh)
+	return node
+}
+
+func (n *qbftNode) Start() {
+	n.engine.Start(n.chain, n.chain.CurrentBlock, rawdb.HasBadBlock)
+	go n.loop()
+}
+
+func (n *qbftNode) Stop() {
+	close(n.exit)
+	n.broadcaster.Stop()
+	time.Sleep(10 * time.Millisecond)
+	n.engine.Stop()
+	n.headSub.Unsubscribe()
+	n.chain.Stop()
+}
+
+func (n *qbftNode) loop() {
+	results := make(chan *types.Block, 1)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case ev := <-n.headCh:
+			n.engine.NewChainHead(ev.Block.Header())
+			n.newWork(results)
+
+		case <-timer.C:
+			n.newWork(results)
+			timer.Reset(2 * time.Second)
+
+		case block := <-results:
+			if block != nil {
+				n.write(block)
+			}
+
+		case <-n.exit:
+			if n.stopSeal != nil {
+				close(n.stopSeal)
+			}
+			return
+		}
+	}
+}
+
+// newWork assembles a block on top of the current head and hands it to the
+// engine, interrupting the previous sealing attempt as miner.worker does.
+func (n *qbftNode) newWork(results chan *types.Block) {
+	if n.stopSeal != nil {
+		close(n.stopSeal)
+		n.stopSeal = nil
+	}
+
+	parent := n.chain.CurrentBlock()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		GasLimit:   math.MaxUint64,
+		Time:       uint64(time.Now().Unix()),
+	}
+	if err := n.engine.Prepare(n.chain, header); err != nil {
+		log.Error("Failed to prepare", "err", err)
+		return
+	}
+
+	statedb, privstate, err := n.chain.StateAt(parent.Root())
+	if err != nil {
+		log.Error("Failed to load state", "err", err)
+		return
+	}
+	txs, receipts := applyPoolTransactions(n.chain.Config(), n.chain, n.txpool, header, statedb, privstate)
+	block, err := n.engine.FinalizeAndAssemble(n.chain, header, statedb, txs, nil, receipts)
+	if err != nil {
+		log.Error("Failed to finalizeAndAssemble", "err", err)
+		return
+	}
+
+	n.pendingMu.Lock()
+	n.pending[n.engine.SealHash(block.Header())] = &qbftTask{receipts: receipts, state: statedb, privstate: privstate}
+	n.pendingMu.Unlock()
+
+	n.stopSeal = make(chan struct{})
+	if err := n.engine.Seal(n.chain, block, results, n.stopSeal); err != nil {
+		log.Error("Block sealing failed", "err", err)
+	}
+}
+
+// write stores a block sealed by this node using the state of the local task
+func (n *qbftNode) write(block *types.Block) {
+	sealHash := n.engine.SealHash(block.Header())
+	n.pendingMu.Lock()
+	task, ok := n.pending[sealHash]
+	n.pending = make(map[common.Hash]*qbftTask)
+	n.pendingMu.Unlock()
+	if !ok {
+		log.Error("Failed to find local task", "hash", block.Hash())
+		return
+	}
+
+	var logs []*types.Log
+	for i, receipt := range task.receipts {
+		receipt.BlockHash = block.Hash()
+		receipt.BlockNumber = block.Number()
+		receipt.TransactionIndex = uint(i)
+		for _, l := range receipt.Logs {
+			l.BlockHash = block.Hash()
+		}
+		logs = append(logs, receipt.Logs...)
+	}
+	if _, err := n.chain.WriteBlockWithState(block, task.receipts, logs, task.state, task.privstate, true); err != nil {
+		log.Error("Failed writing block to chain", "err", err)
+	}
+}
+
+type qbftTask struct {
+	receipts  []*types.Receipt
+	state     *state.StateDB
+	privstate mps.PrivateStateRepository
+}
+
+type qbftBroadcaster struct {
+	node  *qbftNode
+	peers map[common.Address]*qbftPeer
+}
+
+// Enqueue imports blocks committed by other proposers, like the block fetcher
+func (b *qbftBroadcaster) Enqueue(id string, block *types.Block) {
+	go func() {
+		if _, err := b.node.chain.InsertChain(types.Blocks{block}); err != nil {
+			log.Error("Failed to import block", "number", block.Number(), "err", err)
+		}
+	}()
+}
+
+func (b *qbftBroadcaster) FindPeers(targets map[common.Address]bool) map[common.Address]consensus.Peer {
+	m := make(map[common.Address]consensus.Peer)
+	for addr, p := range b.peers {
+		if targets[addr] {
+			m[addr] = p
+		}
+	}
+	return m
+}
+
+func (b *qbftBroadcaster) FindPeer(target common.Address) consensus.Peer {
+	if p, ok := b.peers[target]; ok {
+		return p
+	}
+	return nil
+}
+
+func (b *qbftBroadcaster) Stop() {
+	for _, peer := range b.peers {
+		peer.rw.Close()
+	}
+}
+
+func (b *qbftBroadcaster) Connect(b2 *qbftBroadcaster) {
+	if _, exist := b.peers[b2.node.addr]; exist {
+		return
+	}
+
+	rw1, rw2 := p2p.MsgPipe()
+	b.add(b2.node.addr, rw1)
+	b2.add(b.node.addr, rw2)
+}
+
+func (b *qbftBroadcaster) add(remote common.Address, rw *p2p.MsgPipeRW) {
+	peer := &qbftPeer{rw: rw, node: b.node}
+	b.peers[remote] = peer
+
+	go func() {
+		defer rw.Close()
+
+		for {
+			msg, err := rw.ReadMsg()
+			if err != nil {
+				return
+			}
+			if _, err := b.node.engine.HandleMsg(remote, msg); err != nil {
+				log.Error("Failed to handle message", "err", err)
+				return
+			}
+		}
+	}()
+}
+
+type qbftPeer struct {
+	rw   *p2p.MsgPipeRW
+	node *qbftNode
+}
+
+func (p *qbftPeer) Send(msgcode uint64, data interface{}) error {
+	return p2p.Send(p.rw, msgcode, data)
+}
+
+func (p *qbftPeer) SendConsensus(msgcode uint64, data interface{}) error {
+	return p2p.Send(p.rw, msgcode, data)
+}
+
+func (p *qbftPeer) SendQBFTConsensus(msgcode uint64, payload []byte) error {
+	if err := p2p.SendWithNoEncoding(p.rw, msgcode, payload); err != nil {
+		return err
+	}
+	p.node.stats.Record(len(payload))
+	return nil
+}
+
+// makeQBFTGenesis mirrors makeGenesis with a QBFT extra-data header
+func makeQBFTGenesis(vals []common.Address, alloc core.GenesisAlloc) *core.Genesis {
+	genesis := makeGenesis(vals, alloc)
+	genesis.Config.HotStuff = nil
+	genesis.Config.QBFT = &params.QBFTConfig{BFTConfig: &params.BFTConfig{}}
+	genesis.Mixhash = types.IstanbulDigest
+	genesis.Nonce = 0
+
+	extra, err := rlp.EncodeToBytes(&types.QBFTExtra{
+		VanityData:    bytes.Repeat([]byte{0x00}, types.IstanbulExtraVanity),
+		Validators:    vals,
+		CommittedSeal: [][]byte{},
+	})
+	if err != nil {
+		panic(err)
+	}
+	genesis.ExtraData = extra
+	return genesis
+}
@@ -30,18 +30,62 @@ type Geth struct {
 	broadcaster *broadcaster
 	signer      hs.Signer
 	hook        func(node *Geth, raw []byte) ([]byte, bool)
+	stats       *netStats // optional consensus traffic counters
 }
 
 func MakeGeth(
 	privateKey *ecdsa.PrivateKey,
 	blsInfo *types.BLSInfo,
 	vals []common.Address,
+) *Geth {
+	return makeGethWithConfig(privateKey, blsInfo, vals, nil, hs.DefaultBasicConfig, nil)
+}
+
+// makeGethWithConfig builds a mock node running the given engine config on
+// top of a genesis funding the accounts in alloc. Validators have a weight of
+// 1 if weights is nil.
+func makeGethWithConfig(
+	privateKey *ecdsa.PrivateKey,
+	blsInfo *types.BLSInfo,
+	vals []common.Address,
+	weights []int,
+	config *hs.Config,
+	alloc core.GenesisAlloc,
+) *Geth {
+	signer := snr.NewSigner(
+		privateKey,
+		byte(hs.MsgTypePrepareVote), // Do we need this field?
+		blsInfo,
+	)
+	return makeGethWithSigner(signer, vals, weights, config, alloc)
+}
+
+// makeGethWithSigner builds a mock node signing with the given scheme
+func makeGethWithSigner(
+	signer hs.Signer,
+	vals []common.Address,
+	weights []int,
+	config *hs.Config,
+	alloc core.GenesisAlloc,
+) *Geth {
+	return makeGethWithValSet(signer, makeValSet(vals, weights, config), vals, config, alloc)
+}
+
+// makeGethWithValSet builds a mock node whose engine runs on valset, which the
+// signer may share as in a real node, starting from a genesis recording vals
+func makeGethWithValSet(
+	signer hs.Signer,
+	valset hs.ValidatorSet,
+	vals []common.Address,
+	config *hs.Config,
+	alloc core.GenesisAlloc,
 ) *Geth {
 	db := rawdb.NewMemoryDatabase()
-	engine := makeEngine(privateKey, db, vals, blsInfo)
-	chain := makeChain(db, engine, vals)
+	engine := makeEngine(signer, db, valset, config)
+	chain := makeChain(db, engine, makeGenesis(vals, alloc))
 	hotstuffEngine := engine.(consensus.MockHotStuff)
 	broadcaster := engine.(consensus.Handler).GetBroadcaster().(*broadcaster)
+	engine.(consensus.ChainInserterHandler).SetChainInserter(chain)
 	api := engine.APIs(chain)[0].Service.(*backend.API)
 	miner := makeMiner(broadcaster.addr, chain, hotstuffEngine)
 	geth := &Geth{
@@ -51,11 +95,7 @@ func MakeGeth(
 		api:         api,
 		hotstuff:    hotstuffEngine,
 		broadcaster: broadcaster,
-		signer: snr.NewSigner(
-			privateKey,
-			byte(hs.MsgTypePrepareVote), // Do we need this field?
-			blsInfo,
-		),
+		signer:      signer,
 	}
 	geth.addr = geth.signer.Address()
 	miner.geth = geth
@@ -149,11 +189,59 @@ type System struct {
 }
 
 func makeSystem(n int) *System {
+	return makeSystemWithConfig(n, hs.DefaultBasicConfig)
+}
+
+func makeSystemWithConfig(n int, config *hs.Config) *System {
 	pks, blsinfos, addrs := newAccountLists(n)
 	nodes := make([]*Geth, n)
 
 	for i := 0; i < n; i++ {
-		nodes[i] = MakeGeth(pks[i], blsinfos[i], addrs)
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, nil, config, nil)
+	}
+
+	return &System{nodes: nodes, exit: make(chan struct{})}
+}
+
+// makeWeightedSystem creates a system where nodes[i] has a voting power of weights[i]
+func makeWeightedSystem(weights []int, config *hs.Config) *System {
+	pks, blsinfos, addrs := newWeightedAccountLists(weights)
+	nodes := make([]*Geth, len(weights))
+
+	for i := range nodes {
+		nodes[i] = makeGethWithConfig(pks[i], blsinfos[i], addrs, weights, config, nil)
+	}
+
+	return &System{nodes: nodes, exit: make(chan struct{})}
+}
+
+// makeMultiSigSystem creates a system of n validators using the BLS multi-signature
+// scheme, each node holding its own BLS key pair
+func makeMultiSigSystem(n int, config *hs.Config) *System {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return makeWeightedMultiSigSystem(weights, config)
+}
+
+// makeWeightedMultiSigSystem creates a system of validators with the given weights
+// using the BLS multi-signature scheme
+func makeWeightedMultiSigSystem(weights []int, config *hs.Config) *System {
+	n := len(weights)
+	pks, _, addrs := newAccountLists(n)
+	suite, blsKeys, pubKeys := GenerateMultiSigKeys(addrs)
+
+	multiConfig := *config
+	multiConfig.SignatureScheme = hs.MultiBLS
+	nodes := make([]*Geth, n)
+	for i := 0; i < n; i++ {
+		valset := makeValSet(addrs, weights, &multiConfig)
+		signer, err := snr.NewMultiSigner(pks[i], byte(hs.MsgTypePrepareVote), suite, blsKeys[i], pubKeys, valset)
+		if err != nil {
+			panic(err)
+		}
+		nodes[i] = makeGethWithValSet(signer, valset, addrs, &multiConfig, nil)
 	}
 
 	return &System{nodes: nodes, exit: make(chan struct{})}
@@ -212,29 +300,44 @@ func (s *System) Leader() *Geth {
 }
 
 func newAccountLists(n int) ([]*ecdsa.PrivateKey, []*types.BLSInfo, []common.Address) {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = 1
+	}
+	return newWeightedAccountLists(weights)
+}
+
+// newWeightedAccountLists generates validator keys, giving every validator as many
+// BLS key shares as its weight
+func newWeightedAccountLists(weights []int) ([]*ecdsa.PrivateKey, []*types.BLSInfo, []common.Address) {
+	n := len(weights)
 	pks := make([]*ecdsa.PrivateKey, n)
 	addrs := make([]common.Address, n)
+	total := 0
 	for i := 0; i < n; i++ {
 		key, _ := crypto.GenerateKey()
 		pks[i] = key
 		addrs[i] = crypto.PubkeyToAddress(key.PublicKey)
+		total += weights[i]
 	}
 
 	// BLS Signatures
-	t := Q(n)
-	f := F(n)
-	suite, pubPoly, priPoly := GenerateBLSKeys(n, f)
-	priPolyShares := priPoly.Shares(n)
+	t := Q(total)
+	f := F(total)
+	suite, pubPoly, priPoly := GenerateBLSKeys(total, f)
+	priPolyShares := priPoly.Shares(total)
 	blsinfos := make([]*types.BLSInfo, n)
 
+	offset := 0
 	for i := 0; i < n; i++ {
 		blsinfos[i] = &types.BLSInfo{
-			T:          t,
-			N:          n,
-			Suite:      suite,
-			BLSPrivKey: priPolyShares[i],
-			BLSPubPoly: pubPoly,
+			T:           t,
+			N:           total,
+			Suite:       suite,
+			BLSPrivKeys: priPolyShares[offset : offset+weights[i]],
+			BLSPubPoly:  pubPoly,
 		}
+		offset += weights[i]
 	}
 
 	return pks, blsinfos, addrs
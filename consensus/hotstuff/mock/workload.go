@@ -0,0 +1,304 @@
+package mock
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/mps"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// workloadBalance is the genesis balance of every workload sender
+	workloadBalance = new(big.Int).Mul(big.NewInt(1000000000), big.NewInt(params.Ether))
+
+	// workloadSink receives the value of every workload transfer
+	workloadSink = common.HexToAddress("0x000000000000000000000000000000000000beef")
+)
+
+// txPool is a minimal transaction pool shared by all nodes of a mock network.
+// It stands in for tx gossip: every miner sees the same pending list, and
+// transactions are dropped once the observer node has them on chain.
+type txPool struct {
+	mu      sync.Mutex
+	pending []*types.Transaction
+}
+
+func newTxPool() *txPool {
+	return &txPool{}
+}
+
+func (p *txPool) Add(tx *types.Transaction) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.pending = append(p.pending, tx)
+}
+
+// Get returns the pending transaction with the given hash, or nil if unknown
+func (p *txPool) Get(hash common.Hash) *types.Transaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, tx := range p.pending {
+		if tx.Hash() == hash {
+			return tx
+		}
+	}
+	return nil
+}
+
+// List returns a copy of the pending transactions in submission order
+func (p *txPool) List() []*types.Transaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	txs := make([]*types.Transaction, len(p.pending))
+	copy(txs, p.pending)
+	return txs
+}
+
+// Pending returns the pending transactions grouped by sender, in submission order
+func (p *txPool) Pending() (map[common.Address]types.Transactions, error) {
+	pending := make(map[common.Address]types.Transactions)
+	for _, tx := range p.List() {
+		from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, err
+		}
+		pending[from] = append(pending[from], tx)
+	}
+	return pending, nil
+}
+
+// Remove drops the given transactions from the pending list
+func (p *txPool) Remove(txs types.Transactions) {
+	if len(txs) == 0 {
+		return
+	}
+	included := make(map[common.Hash]struct{}, len(txs))
+	for _, tx := range txs {
+		included[tx.Hash()] = struct{}{}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remain := p.pending[:0]
+	for _, tx := range p.pending {
+		if _, ok := included[tx.Hash()]; !ok {
+			remain = append(remain, tx)
+		}
+	}
+	p.pending = remain
+}
+
+func (p *txPool) Size() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.pending)
+}
+
+// applyPoolTransactions applies pending pool transactions on top of statedb, filling
+// header.GasUsed. Transactions with an unexpected nonce are skipped silently, since
+// the pool may still hold transactions of a block which is being committed.
+func applyPoolTransactions(
+	config *params.ChainConfig,
+	chain core.ChainContext,
+	pool *txPool,
+	header *types.Header,
+	statedb *state.StateDB,
+	privateStateRepo mps.PrivateStateRepository,
+) (types.Transactions, []*types.Receipt) {
+	if pool == nil {
+		return nil, nil
+	}
+
+	var (
+		signer   = types.LatestSigner(config)
+		gasPool  = new(core.GasPool).AddGas(header.GasLimit)
+		coinbase = header.Coinbase
+		txs      types.Transactions
+		receipts []*types.Receipt
+	)
+
+	privateStateDB, err := privateStateRepo.DefaultState()
+	if err != nil {
+		log.Error("Failed to load private state", "err", err)
+		return nil, nil
+	}
+	for _, tx := range pool.List() {
+		from, err := types.Sender(signer, tx)
+		if err != nil || statedb.GetNonce(from) != tx.Nonce() {
+			continue
+		}
+		snap := statedb.Snapshot()
+		statedb.Prepare(tx.Hash(), common.Hash{}, len(txs))
+		privateStateDB.Prepare(tx.Hash(), common.Hash{}, len(txs))
+		receipt, _, err := core.ApplyTransaction(config, chain, &coinbase, gasPool, statedb, privateStateDB, header, tx, &header.GasUsed, vm.Config{}, false, privateStateRepo, false)
+		if err != nil {
+			statedb.RevertToSnapshot(snap)
+			if err == core.ErrGasLimitReached {
+				break
+			}
+			continue
+		}
+		txs = append(txs, tx)
+		receipts = append(receipts, receipt)
+	}
+	return txs, receipts
+}
+
+// txFeeder signs value transfers from a fixed set of funded accounts and pushes
+// them into a txPool at a constant rate.
+type txFeeder struct {
+	signer types.Signer
+	keys   []*ecdsa.PrivateKey
+	nonces []uint64
+	rate   int // transactions per second
+
+	pool *txPool
+
+	mu        sync.Mutex
+	submitted map[common.Hash]time.Time
+
+	exit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newTxFeeder(config *params.ChainConfig, keys []*ecdsa.PrivateKey, rate int, pool *txPool) *txFeeder {
+	return &txFeeder{
+		signer:    types.LatestSigner(config),
+		keys:      keys,
+		nonces:    make([]uint64, len(keys)),
+		rate:      rate,
+		pool:      pool,
+		submitted: make(map[common.Hash]time.Time),
+		exit:      make(chan struct{}),
+	}
+}
+
+// newWorkloadAccounts generates n sender keys and the genesis allocation funding them
+func newWorkloadAccounts(n int) ([]*ecdsa.PrivateKey, core.GenesisAlloc) {
+	keys := make([]*ecdsa.PrivateKey, n)
+	alloc := make(core.GenesisAlloc)
+	for i := 0; i < n; i++ {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = core.GenesisAccount{Balance: workloadBalance}
+	}
+	return keys, alloc
+}
+
+func (f *txFeeder) Start() {
+	if f.rate <= 0 {
+		return
+	}
+	f.wg.Add(1)
+	go f.loop()
+}
+
+func (f *txFeeder) Stop() {
+	close(f.exit)
+	f.wg.Wait()
+}
+
+// loop submits transactions on a 10ms tick, catching up with the configured
+// rate so that high rates are not capped by the ticker resolution.
+func (f *txFeeder) loop() {
+	defer f.wg.Done()
+
+	var (
+		start  = time.Now()
+		ticker = time.NewTicker(10 * time.Millisecond)
+		sent   = 0
+		next   = 0
+	)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			due := int(now.Sub(start).Seconds() * float64(f.rate))
+			for ; sent < due; sent++ {
+				tx, err := f.nextTx(next)
+				if err != nil {
+					log.Error("Failed to sign workload transaction", "err", err)
+					return
+				}
+				next = (next + 1) % len(f.keys)
+
+				f.mu.Lock()
+				f.submitted[tx.Hash()] = time.Now()
+				f.mu.Unlock()
+				f.pool.Add(tx)
+			}
+		case <-f.exit:
+			return
+		}
+	}
+}
+
+func (f *txFeeder) nextTx(i int) (*types.Transaction, error) {
+	tx := types.NewTransaction(f.nonces[i], workloadSink, common.Big1, params.TxGas, common.Big0, nil)
+	signed, err := types.SignTx(tx, f.signer, f.keys[i])
+	if err != nil {
+		return nil, err
+	}
+	f.nonces[i]++
+	return signed, nil
+}
+
+// Confirm returns the submission-to-commit latency of every workload transaction in
+// the block, and forgets about them.
+func (f *txFeeder) Confirm(block *types.Block, at time.Time) []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	latencies := make([]time.Duration, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		if submitted, ok := f.submitted[tx.Hash()]; ok {
+			latencies = append(latencies, at.Sub(submitted))
+			delete(f.submitted, tx.Hash())
+		}
+	}
+	return latencies
+}
+
+// Submitted returns the number of transactions submitted so far. It must not
+// be called while the feeder is running.
+func (f *txFeeder) Submitted() uint64 {
+	var n uint64
+	for _, nonce := range f.nonces {
+		n += nonce
+	}
+	return n
+}
+
+// netStats counts the consensus messages sent over the mock network
+type netStats struct {
+	msgs  uint64
+	bytes uint64
+}
+
+func (s *netStats) Record(size int) {
+	if s == nil {
+		return
+	}
+	atomic.AddUint64(&s.msgs, 1)
+	atomic.AddUint64(&s.bytes, uint64(size))
+}
+
+func (s *netStats) Load() (uint64, uint64) {
+	return atomic.LoadUint64(&s.msgs), atomic.LoadUint64(&s.bytes)
+}
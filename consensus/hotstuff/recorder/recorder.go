@@ -0,0 +1,229 @@
+// Package recorder writes the events handled by a HotStuff core to a rotating file,
+// so that a failed view can be replayed step by step.
+package recorder
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// defaultMaxSize is the size in megabytes at which a recording rotates by default
+	defaultMaxSize = 64
+
+	// backups is the number of rotated files kept next to the recording, named
+	// <path>.1 (newest) to <path>.3 (oldest)
+	backups = 3
+)
+
+// Kind identifies the event of a record
+type Kind uint8
+
+const (
+	Start          Kind = iota // Core started, Data is the RLP of the head header, Src the local address
+	Request                    // Block requested by the miner, Data is the RLP of the block
+	Message                    // Message received, including own ones, Data is its payload
+	Backlog                    // Backlogged or authenticated message handled, Data is the RLP of the message
+	Propose                    // Scheduled proposal fired, Data is the RLP of its view
+	Timeout                    // Round timed out
+	FinalCommitted             // Chain head changed, Data is the RLP of the header
+	Sent                       // Message sent, Data is its payload
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Start:
+		return "Start"
+	case Request:
+		return "Request"
+	case Message:
+		return "Message"
+	case Backlog:
+		return "Backlog"
+	case Propose:
+		return "Propose"
+	case Timeout:
+		return "Timeout"
+	case FinalCommitted:
+		return "FinalCommitted"
+	case Sent:
+		return "Sent"
+	default:
+		return fmt.Sprintf("Unknown(%d)", uint8(k))
+	}
+}
+
+// Record is an event handled by the core, in the order it was handled
+type Record struct {
+	Time uint64 // Unix time in nanoseconds
+	Kind Kind
+	Src  common.Address
+	Data []byte
+}
+
+// Recorder appends records to a file, which is rotated once it exceeds its maximum
+// size. Every file starts with a Start record, so that each can be replayed on its
+// own. A nil Recorder records nothing.
+type Recorder struct {
+	mu      sync.Mutex
+	path    string
+	maxSize uint64
+	file    *os.File
+	size    uint64
+
+	address common.Address // Local address, taken from the Start record
+	head    []byte         // RLP of the head header, taken from Start and FinalCommitted records
+}
+
+// New opens the recording at path for appending. The file is rotated once it
+// exceeds maxSize megabytes, 64 if 0.
+func New(path string, maxSize uint64) (*Recorder, error) {
+	if maxSize == 0 {
+		maxSize = defaultMaxSize
+	}
+	r := &Recorder{path: path, maxSize: maxSize * 1024 * 1024}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recorder) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	r.file, r.size = file, uint64(info.Size())
+	return nil
+}
+
+// rotate moves the recording to <path>.1, shifting older files up to the number of
+// backups kept. The new file starts with a Start record at the current head, as the
+// replay of a file starts over from there.
+func (r *Recorder) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	for i := backups - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return err
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	if r.head == nil {
+		return nil
+	}
+	enc, err := encodeRecord(Start, r.address, r.head)
+	if err != nil {
+		return err
+	}
+	return r.write(enc)
+}
+
+func encodeRecord(kind Kind, src common.Address, data []byte) ([]byte, error) {
+	return rlp.EncodeToBytes(&Record{
+		Time: uint64(time.Now().UnixNano()),
+		Kind: kind,
+		Src:  src,
+		Data: data,
+	})
+}
+
+func (r *Recorder) write(enc []byte) error {
+	n, err := r.file.Write(enc)
+	r.size += uint64(n)
+	return err
+}
+
+// Record appends an event at the current time. Failures are logged, the core keeps
+// running without a complete recording.
+func (r *Recorder) Record(kind Kind, src common.Address, data []byte) {
+	if r == nil {
+		return
+	}
+	enc, err := encodeRecord(kind, src, data)
+	if err != nil {
+		log.Warn("Failed to encode consensus record", "kind", kind, "err", err)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return
+	}
+	if r.size > 0 && r.size+uint64(len(enc)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			log.Warn("Failed to rotate consensus recording", "path", r.path, "err", err)
+			r.file = nil
+			return
+		}
+	}
+	if err := r.write(enc); err != nil {
+		log.Warn("Failed to write consensus record", "path", r.path, "err", err)
+	}
+	switch kind {
+	case Start:
+		r.address, r.head = src, data
+	case FinalCommitted:
+		r.head = data
+	}
+}
+
+// Close closes the recording
+func (r *Recorder) Close() error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// ReadFile reads all records of a recording. Rotated files are read separately,
+// oldest first.
+func ReadFile(path string) ([]*Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var (
+		records []*Record
+		stream  = rlp.NewStream(bufio.NewReader(file), 0)
+	)
+	for {
+		record := new(Record)
+		if err := stream.Decode(record); errors.Is(err, io.EOF) {
+			return records, nil
+		} else if err != nil {
+			return records, fmt.Errorf("record %d: %w", len(records), err)
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,127 @@
+package replay
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// backend stands in for the node of a recording. It holds no state: proposals are
+// accepted as they were by the recorded node, and the chain consists of the recorded
+// head and the blocks committed during the replay.
+type backend struct {
+	address common.Address
+	valset  hs.ValidatorSet
+	signer  hs.Signer
+	mux     *event.TypeMux
+
+	head      *types.Block
+	headers   map[uint64]*types.Header // Chain known to the replay, by number
+	committed []*types.Block           // Blocks committed during the replay
+	sent      []*hs.Message            // Messages sent since the last step
+}
+
+func newBackend(address common.Address, valset hs.ValidatorSet, signer hs.Signer) *backend {
+	return &backend{
+		address: address,
+		valset:  valset,
+		signer:  signer,
+		mux:     new(event.TypeMux),
+		headers: make(map[uint64]*types.Header),
+	}
+}
+
+// setHead moves the chain head to the given header, as a chain import would
+func (b *backend) setHead(header *types.Header) {
+	b.head = types.NewBlockWithHeader(header)
+	b.headers[header.Number.Uint64()] = header
+}
+
+func (b *backend) Address() common.Address { return b.address }
+
+func (b *backend) Validators() hs.ValidatorSet { return b.valset }
+
+func (b *backend) EventMux() *event.TypeMux { return b.mux }
+
+func (b *backend) send(payload []byte) error {
+	msg := new(hs.Message)
+	if err := rlp.DecodeBytes(payload, msg); err != nil {
+		return err
+	}
+	b.sent = append(b.sent, msg)
+	return nil
+}
+
+func (b *backend) Broadcast(valSet hs.ValidatorSet, payload []byte) error { return b.send(payload) }
+
+func (b *backend) Gossip(valSet hs.ValidatorSet, payload []byte) error { return b.send(payload) }
+
+func (b *backend) Unicast(valSet hs.ValidatorSet, payload []byte) error { return b.send(payload) }
+
+// Commit moves the head to the committed block, as replicas write it at once
+func (b *backend) Commit(executed *consensus.ExecutedBlock) error {
+	b.committed = append(b.committed, executed.Block)
+	b.setHead(executed.Block.Header())
+	return nil
+}
+
+func (b *backend) Verify(*types.Block) (time.Duration, error) { return 0, nil }
+
+func (b *backend) LastProposal() (*types.Block, common.Address) {
+	if b.head == nil || b.head.NumberU64() == 0 {
+		return b.head, common.Address{}
+	}
+	proposer, _ := b.signer.RecoverSigner(b.head.Header())
+	return b.head, proposer
+}
+
+func (b *backend) HasProposal(hash common.Hash, number *big.Int) bool {
+	header := b.headers[number.Uint64()]
+	return header != nil && header.Hash() == hash
+}
+
+func (b *backend) GetProposer(number uint64) common.Address {
+	if header := b.headers[number]; header != nil && number > 0 {
+		proposer, _ := b.signer.RecoverSigner(header)
+		return proposer
+	}
+	return common.Address{}
+}
+
+// CommitHistory is not recorded, the Reputation policy falls back to its defaults
+func (b *backend) CommitHistory(number uint64, window uint64) []hs.CommitRecord { return nil }
+
+// UpdateValidators keeps the recorded validator set
+func (b *backend) UpdateValidators(parent *types.Header) (hs.ValidatorSet, error) { return nil, nil }
+
+// SuspectCensorship is not recorded, as the transaction pool is not
+func (b *backend) SuspectCensorship(block *types.Block, proposer common.Address) bool { return false }
+
+func (b *backend) HasBadProposal(hash common.Hash) bool { return false }
+
+// Speculate does nothing, as no block is mined during a replay
+func (b *backend) Speculate(executed *consensus.ExecutedBlock) {}
+
+func (b *backend) ExecuteBlock(block *types.Block) (*consensus.ExecutedBlock, error) {
+	return &consensus.ExecutedBlock{Block: block}, nil
+}
+
+func (b *backend) SealBlock(block *types.Block, commitQC *hs.QuorumCert) (*types.Block, error) {
+	encodedQC, err := hs.Encode(commitQC)
+	if err != nil {
+		return nil, err
+	}
+	header := block.Header()
+	if err := header.SetEncodedQC(encodedQC); err != nil {
+		return nil, err
+	}
+	return block.WithSeal(header), nil
+}
+
+func (b *backend) Close() error { return nil }
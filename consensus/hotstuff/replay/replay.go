@@ -0,0 +1,77 @@
+// Package replay feeds a consensus recording into a fresh HotStuff core, so that a
+// failed view can be reproduced locally one event at a time.
+package replay
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	hsc "github.com/ethereum/go-ethereum/consensus/hotstuff/core"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/recorder"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var errNoStart = errors.New("recording does not start with a Start record")
+
+// signer acts as the recorded node. Messages received are authenticated as usual,
+// while the messages the replay sends are signed with the key of the signer instead,
+// and never delivered.
+type signer struct {
+	hs.Signer
+	address common.Address
+}
+
+func (s *signer) Address() common.Address { return s.address }
+
+// Replayer replays the records of a single node
+type Replayer struct {
+	core    *hsc.Core
+	backend *backend
+}
+
+// New creates a replayer for the node which made records, whose first record must
+// be a Start record. Received messages are authenticated with the validator set and
+// signer given, as configured on the recorded chain.
+func New(records []*recorder.Record, config *hs.Config, valset hs.ValidatorSet, sig hs.Signer) (*Replayer, error) {
+	if len(records) == 0 || records[0].Kind != recorder.Start {
+		return nil, errNoStart
+	}
+	address := records[0].Src
+	replayConfig := *config
+	replayConfig.RecordFile = ""
+
+	sig = &signer{Signer: sig, address: address}
+	b := newBackend(address, valset, sig)
+	return &Replayer{
+		core:    hsc.NewReplay(b, &replayConfig, sig, valset),
+		backend: b,
+	}, nil
+}
+
+// Step replays a record, and returns the messages the core sent meanwhile. Records
+// moving the chain head, i.e. Start and FinalCommitted, are applied to the chain
+// first, as they were by the recorded node.
+func (r *Replayer) Step(rec *recorder.Record) ([]*hs.Message, error) {
+	if rec.Kind == recorder.Start || rec.Kind == recorder.FinalCommitted {
+		var header *types.Header
+		if err := rlp.DecodeBytes(rec.Data, &header); err != nil {
+			return nil, err
+		}
+		r.backend.setHead(header)
+	}
+	r.backend.sent = nil
+	err := r.core.Replay(rec)
+	return r.backend.sent, err
+}
+
+// State returns the view and state reached by the replay so far
+func (r *Replayer) State() (*hs.View, hs.State) {
+	return r.core.ReplayState()
+}
+
+// Committed returns the blocks committed during the replay
+func (r *Replayer) Committed() []*types.Block {
+	return r.backend.committed
+}
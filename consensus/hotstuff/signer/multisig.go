@@ -0,0 +1,429 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	lru "github.com/hashicorp/golang-lru"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign/bls"
+)
+
+// popDomain separates proofs of possession from consensus signatures
+var popDomain = []byte("HOTSTUFF_BLS_POP")
+
+var (
+	errUnknownBLSKey   = errors.New("unknown BLS public key")
+	errInvalidBLSProof = errors.New("invalid BLS proof of possession")
+)
+
+// BLSPublicKey is a validator BLS public key registered for the multi-signature
+// scheme, together with its proof of possession.
+type BLSPublicKey struct {
+	Address   common.Address
+	PublicKey []byte // Marshalled G2 point
+	Proof     []byte // BLS signature over popDomain || PublicKey
+}
+
+// multiSig is the QC signature of the multi-signature scheme
+type multiSig struct {
+	Signers   []byte // Bitmap of signers, indexed by validator set position
+	Signature []byte // Aggregated BLS signature
+}
+
+// MultiSigner implements hs.Signer with a BLS multi-signature scheme: every validator
+// signs with its own BLS key, and QCs carry the aggregated signature along with a
+// bitmap of signers. Unlike the threshold scheme, keys are not tied to a fixed n, so
+// the validator set can change without resharing.
+//
+// Validator public keys must come with a proof of possession, which rules out
+// rogue-key attacks on the aggregated public key.
+type MultiSigner struct {
+	*HotstuffSigner
+
+	blsKey kyber.Scalar
+
+	valSetMu sync.RWMutex
+	valSet   hs.ValidatorSet                              // Validator set whose votes are aggregated
+	history  func(height uint64) (hs.ValidatorSet, error) // Validator sets of past heights, nil if the set is fixed
+
+	keysMu sync.RWMutex
+	keys   map[common.Address]kyber.Point
+}
+
+// NewMultiSigner creates a multi-signature signer for the given validator set. Keys
+// with an invalid proof of possession are rejected.
+func NewMultiSigner(
+	privateKey *ecdsa.PrivateKey,
+	commitMsgType byte,
+	suite *bn256.Suite,
+	blsKey kyber.Scalar,
+	pubKeys []*BLSPublicKey,
+	valSet hs.ValidatorSet,
+) (*MultiSigner, error) {
+	signatures, _ := lru.NewARC(inmemorySignatures)
+	s := &MultiSigner{
+		HotstuffSigner: &HotstuffSigner{
+			address:       crypto.PubkeyToAddress(privateKey.PublicKey),
+			privateKey:    privateKey,
+			signatures:    signatures,
+			commitSigSalt: commitMsgType,
+			suite:         suite,
+			logger:        log.New(),
+		},
+		blsKey: blsKey,
+		valSet: valSet,
+		keys:   make(map[common.Address]kyber.Point),
+	}
+	for _, key := range pubKeys {
+		if err := s.AddPublicKey(key); err != nil {
+			return nil, fmt.Errorf("validator %s: %v", key.Address.Hex(), err)
+		}
+	}
+	return s, nil
+}
+
+// NewBLSProof creates the proof of possession of a BLS key pair
+func NewBLSProof(suite *bn256.Suite, blsKey kyber.Scalar, pubKey []byte) ([]byte, error) {
+	return bls.Sign(suite, blsKey, append(append([]byte{}, popDomain...), pubKey...))
+}
+
+// AddPublicKey registers the BLS public key of a validator after checking its proof
+// of possession, e.g. when a validator joins the set
+func (s *MultiSigner) AddPublicKey(key *BLSPublicKey) error {
+	point, err := s.verifyPublicKey(key)
+	if err != nil {
+		return err
+	}
+
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+
+	s.keys[key.Address] = point
+	return nil
+}
+
+// VerifyPublicKey checks the proof of possession of a BLS public key, without
+// registering it
+func (s *MultiSigner) VerifyPublicKey(key *BLSPublicKey) error {
+	_, err := s.verifyPublicKey(key)
+	return err
+}
+
+func (s *MultiSigner) verifyPublicKey(key *BLSPublicKey) (kyber.Point, error) {
+	point := s.suite.G2().Point()
+	if err := point.UnmarshalBinary(key.PublicKey); err != nil {
+		return nil, err
+	}
+	msg := append(append([]byte{}, popDomain...), key.PublicKey...)
+	if err := bls.Verify(s.suite, point, msg, key.Proof); err != nil {
+		return nil, errInvalidBLSProof
+	}
+	return point, nil
+}
+
+// HasPublicKey reports whether the BLS public key of a validator is registered
+func (s *MultiSigner) HasPublicKey(addr common.Address) bool {
+	_, ok := s.publicKey(addr)
+	return ok
+}
+
+// SetValidators replaces the validator set whose votes are aggregated, when the set
+// changes at a new height
+func (s *MultiSigner) SetValidators(valSet hs.ValidatorSet) {
+	s.valSetMu.Lock()
+	defer s.valSetMu.Unlock()
+
+	s.valSet = valSet
+}
+
+// SetValidatorHistory sets the source of the validator sets of past heights, as the
+// signer bitmap of a QC indexes the set of its height. Without it QCs are verified
+// with the current set.
+func (s *MultiSigner) SetValidatorHistory(history func(height uint64) (hs.ValidatorSet, error)) {
+	s.valSetMu.Lock()
+	defer s.valSetMu.Unlock()
+
+	s.history = history
+}
+
+// validators returns the validator set whose votes are aggregated
+func (s *MultiSigner) validators() hs.ValidatorSet {
+	s.valSetMu.RLock()
+	defer s.valSetMu.RUnlock()
+
+	return s.valSet
+}
+
+// qcValidators returns the validator set which signed a QC
+func (s *MultiSigner) qcValidators(qc *hs.QuorumCert) (hs.ValidatorSet, error) {
+	s.valSetMu.RLock()
+	history := s.history
+	s.valSetMu.RUnlock()
+
+	if history == nil || qc.View == nil || qc.View.Height == nil {
+		return s.validators(), nil
+	}
+	return history(qc.View.Height.Uint64())
+}
+
+func (s *MultiSigner) publicKey(addr common.Address) (kyber.Point, bool) {
+	s.keysMu.RLock()
+	defer s.keysMu.RUnlock()
+
+	point, ok := s.keys[addr]
+	return point, ok
+}
+
+// BLSSign
+//   - Sign bytes using the validator BLS key
+//   - The signature is prefixed with the signer address so that the leader can
+//     build the signer bitmap
+func (s *MultiSigner) BLSSign(data []byte) ([]byte, error) {
+	sig, err := bls.Sign(s.suite, s.blsKey, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(s.address.Bytes(), sig...), nil
+}
+
+// BLSRecoverAggSig
+//   - Verify vote signatures and aggregate them into a multiSig
+func (s *MultiSigner) BLSRecoverAggSig(data []byte, sigShares [][]byte) ([]byte, error) {
+	valSet := s.validators()
+	sig, weight, err := s.aggregate(valSet, data, sigShares)
+	if err != nil {
+		return nil, err
+	}
+	if weight < valSet.Q() {
+		return nil, errInsufficientAggPub
+	}
+	return rlp.EncodeToBytes(sig)
+}
+
+// AggregateShares
+//   - Verify vote signatures and aggregate them into a single share signed by all
+//     of their signers, e.g. the votes of a subtree of the overlay tree. Unlike a
+//     QC the share needs no quorum, and is aggregated again by BLSRecoverAggSig.
+func (s *MultiSigner) AggregateShares(data []byte, sigShares [][]byte) ([]byte, error) {
+	sig, _, err := s.aggregate(s.validators(), data, sigShares)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := rlp.EncodeToBytes(sig)
+	if err != nil {
+		return nil, err
+	}
+	return append(common.Address{}.Bytes(), enc...), nil
+}
+
+// ShareSigners
+//   - Verify a vote share, signed by a single validator or aggregated by
+//     AggregateShares, and return its signers
+func (s *MultiSigner) ShareSigners(data []byte, share []byte) ([]common.Address, error) {
+	valSet := s.validators()
+	sig, _, err := s.aggregate(valSet, data, [][]byte{share})
+	if err != nil {
+		return nil, err
+	}
+	return bitmapSigners(sig.Signers, valSet), nil
+}
+
+// aggregate verifies vote shares and aggregates them, returning the voting power of
+// their signers. A share is either the signature of a single validator prefixed with
+// its address, or a multiSig prefixed with the zero address, which is no validator.
+// A signature cannot be taken out of an aggregate, so aggregated shares are taken
+// first, those overlapping with the signers counted already are skipped, and single
+// shares of counted signers, or of validators whose key is not registered yet, are
+// skipped.
+func (s *MultiSigner) aggregate(valSet hs.ValidatorSet, data []byte, sigShares [][]byte) (*multiSig, int, error) {
+	var (
+		vals    = valSet.List()
+		signers = make([]byte, (len(vals)+7)/8)
+		sigs    = make([][]byte, 0, len(sigShares))
+		weight  = 0
+		singles = make([][]byte, 0, len(sigShares))
+	)
+	for _, share := range sigShares {
+		if len(share) <= common.AddressLength {
+			return nil, 0, errInvalidSignature
+		}
+		if common.BytesToAddress(share[:common.AddressLength]) != (common.Address{}) {
+			singles = append(singles, share)
+			continue
+		}
+		var agg multiSig
+		if err := rlp.DecodeBytes(share[common.AddressLength:], &agg); err != nil {
+			return nil, 0, errInvalidAggregatedSig
+		}
+		if len(agg.Signers) != len(signers) {
+			return nil, 0, errIncorrectAggInfo
+		}
+		overlap := false
+		for i := range signers {
+			overlap = overlap || agg.Signers[i]&signers[i] != 0
+		}
+		if overlap {
+			continue
+		}
+		aggWeight, err := s.verifyAggregate(vals, data, &agg)
+		if err != nil {
+			return nil, 0, err
+		}
+		for i := range signers {
+			signers[i] |= agg.Signers[i]
+		}
+		sigs = append(sigs, agg.Signature)
+		weight += aggWeight
+	}
+	for _, share := range singles {
+		addr, sig := common.BytesToAddress(share[:common.AddressLength]), share[common.AddressLength:]
+
+		index, val := valSet.GetByAddress(addr)
+		if val == nil {
+			return nil, 0, errUnauthorizedAddress
+		}
+		if signers[index/8]&(1<<uint(index%8)) != 0 {
+			continue
+		}
+		pubKey, ok := s.publicKey(addr)
+		if !ok {
+			continue
+		}
+		if err := bls.Verify(s.suite, pubKey, data, sig); err != nil {
+			return nil, 0, err
+		}
+		signers[index/8] |= 1 << uint(index%8)
+		sigs = append(sigs, sig)
+		weight += val.Weight()
+	}
+	if len(sigs) == 0 {
+		return nil, 0, errInsufficientAggPub
+	}
+
+	aggSig, err := bls.AggregateSignatures(s.suite, sigs...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &multiSig{Signers: signers, Signature: aggSig}, weight, nil
+}
+
+// BLSVerifyAggSig
+//   - Verify a multiSig on intended data against the aggregated public key of
+//     the signers, which must hold a quorum
+func (s *MultiSigner) BLSVerifyAggSig(data []byte, aggSig []byte) error {
+	return s.verifyAggSig(s.validators(), data, aggSig)
+}
+
+func (s *MultiSigner) verifyAggSig(valSet hs.ValidatorSet, data []byte, aggSig []byte) error {
+	var sig multiSig
+	if err := rlp.DecodeBytes(aggSig, &sig); err != nil {
+		return errInvalidAggregatedSig
+	}
+
+	vals := valSet.List()
+	if len(sig.Signers) != (len(vals)+7)/8 {
+		return errIncorrectAggInfo
+	}
+	weight, err := s.verifyAggregate(vals, data, &sig)
+	if err != nil {
+		return err
+	}
+	if weight < valSet.Q() {
+		return errInsufficientAggPub
+	}
+	return nil
+}
+
+// verifyAggregate verifies a multiSig against the aggregated public key of its
+// signers, and returns their voting power
+func (s *MultiSigner) verifyAggregate(vals []hs.Validator, data []byte, sig *multiSig) (int, error) {
+	var (
+		pubKeys = make([]kyber.Point, 0, len(vals))
+		weight  = 0
+	)
+	for i, val := range vals {
+		if sig.Signers[i/8]&(1<<uint(i%8)) == 0 {
+			continue
+		}
+		pubKey, ok := s.publicKey(val.Address())
+		if !ok {
+			return 0, errUnknownBLSKey
+		}
+		pubKeys = append(pubKeys, pubKey)
+		weight += val.Weight()
+	}
+	if len(pubKeys) == 0 {
+		return 0, errInsufficientAggPub
+	}
+	if err := bls.Verify(s.suite, bls.AggregatePublicKeys(s.suite, pubKeys...), data, sig.Signature); err != nil {
+		return 0, err
+	}
+	return weight, nil
+}
+
+// AuthQC
+//   - Authenticates QC signature against contents using the multi-signature of
+//     the validator set at the QC height
+func (s *MultiSigner) AuthQC(qc *hs.QuorumCert) error {
+	valSet, err := s.qcValidators(qc)
+	if err != nil {
+		return err
+	}
+	return s.authQCWith(valSet, qc)
+}
+
+func (s *MultiSigner) authQCWith(valSet hs.ValidatorSet, qc *hs.QuorumCert) error {
+	return authQC(qc, func(data []byte, aggSig []byte) error {
+		return s.verifyAggSig(valSet, data, aggSig)
+	})
+}
+
+// VerifyHeader
+//   - Verifies block header fields and the multi-signature of its QC against
+//     valSet, the validator set governing the header
+func (s *MultiSigner) VerifyHeader(header *types.Header, valSet hs.ValidatorSet, seal bool) error {
+	return s.verifyHeader(header, valSet, seal, func(qc *hs.QuorumCert) error {
+		return s.authQCWith(valSet, qc)
+	})
+}
+
+// Signers returns the addresses of the validators which signed a QC
+func (s *MultiSigner) Signers(qc *hs.QuorumCert) ([]common.Address, error) {
+	valSet, err := s.qcValidators(qc)
+	if err != nil {
+		return nil, err
+	}
+	return QCSigners(qc, valSet)
+}
+
+// QCSigners returns the signers of a multi-signature QC, whose bitmap is indexed by
+// the positions of the validator set of its height, e.g. the set recorded in the
+// header it certifies
+func QCSigners(qc *hs.QuorumCert, valSet hs.ValidatorSet) ([]common.Address, error) {
+	var sig multiSig
+	if err := rlp.DecodeBytes(qc.BLSSignature, &sig); err != nil {
+		return nil, errInvalidAggregatedSig
+	}
+	return bitmapSigners(sig.Signers, valSet), nil
+}
+
+// bitmapSigners returns the validators set in a signer bitmap
+func bitmapSigners(bitmap []byte, valSet hs.ValidatorSet) []common.Address {
+	var addrs []common.Address
+	for i, val := range valSet.List() {
+		if i/8 < len(bitmap) && bitmap[i/8]&(1<<uint(i%8)) != 0 {
+			addrs = append(addrs, val.Address())
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	errRemoteSignerClosed = errors.New("remote signer closed")
+	errRemoteSignature    = errors.New("remote signature does not match validator address")
+)
+
+// RemoteKeys holds the validator keys of a RemoteSigner outside the node, e.g. in
+// a signer plugin backed by an HSM
+type RemoteKeys interface {
+	// Address returns the validator address of the ECDSA key
+	Address(ctx context.Context) (common.Address, error)
+
+	// Sign signs a hash with the ECDSA key, in the [R || S || V] format
+	Sign(ctx context.Context, hash common.Hash) ([]byte, error)
+
+	// BLSSign signs data with the BLS key or key shares of the validator, in the
+	// format of the scheme: concatenated index-prefixed shares for the threshold
+	// scheme, a plain BLS signature for the multi-signature scheme
+	BLSSign(ctx context.Context, data []byte) ([]byte, error)
+}
+
+// RemoteSigner implements hs.Signer with remote validator keys. Signing goes to
+// the RemoteKeys, every call bounded by a timeout and canceled on Close, while
+// verification and aggregation stay with the local signer, which only needs the
+// public keys.
+type RemoteSigner struct {
+	hs.Signer
+
+	keys    RemoteKeys
+	address common.Address
+	multi   bool // Whether BLS signatures are prefixed with the signer address
+	timeout time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	logger log.Logger
+}
+
+// NewRemoteSigner creates a signer delegating to keys, with the signing scheme of
+// local. The validator address is fetched from the keys.
+func NewRemoteSigner(local hs.Signer, keys RemoteKeys, timeout time.Duration) (*RemoteSigner, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &RemoteSigner{
+		Signer:  local,
+		keys:    keys,
+		timeout: timeout,
+		ctx:     ctx,
+		cancel:  cancel,
+		logger:  log.New(),
+	}
+	_, s.multi = local.(*MultiSigner)
+
+	callCtx, callCancel := s.callContext()
+	defer callCancel()
+	address, err := keys.Address(callCtx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("remote signer address: %w", err)
+	}
+	s.address = address
+	return s, nil
+}
+
+// callContext returns the context of a single remote call
+func (s *RemoteSigner) callContext() (context.Context, context.CancelFunc) {
+	if s.timeout == 0 {
+		return context.WithCancel(s.ctx)
+	}
+	return context.WithTimeout(s.ctx, s.timeout)
+}
+
+// Close cancels pending remote calls and fails the next ones
+func (s *RemoteSigner) Close() error {
+	s.cancel()
+	return nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) BLSSign(data []byte) ([]byte, error) {
+	if s.ctx.Err() != nil {
+		return nil, errRemoteSignerClosed
+	}
+	ctx, cancel := s.callContext()
+	defer cancel()
+
+	start := time.Now()
+	sig, err := s.keys.BLSSign(ctx, data)
+	if err != nil {
+		s.logger.Warn("Remote BLS signing failed", "elapsed", common.PrettyDuration(time.Since(start)), "err", err)
+		return nil, err
+	}
+	if len(sig) == 0 {
+		return nil, hs.ErrInvalidSignature
+	}
+	if s.multi {
+		sig = append(s.address.Bytes(), sig...)
+	}
+	return sig, nil
+}
+
+func (s *RemoteSigner) Sign(hash common.Hash) ([]byte, error) {
+	if hash == hs.EmptyHash {
+		return nil, hs.ErrInvalidRawHash
+	}
+	if s.ctx.Err() != nil {
+		return nil, errRemoteSignerClosed
+	}
+	ctx, cancel := s.callContext()
+	defer cancel()
+
+	start := time.Now()
+	sig, err := s.keys.Sign(ctx, hash)
+	if err != nil {
+		s.logger.Warn("Remote signing failed", "elapsed", common.PrettyDuration(time.Since(start)), "err", err)
+		return nil, err
+	}
+	// a signature of another key would only be rejected by peers
+	if signer, err := getSignatureAddress(hash, sig); err != nil || signer != s.address {
+		return nil, errRemoteSignature
+	}
+	return sig, nil
+}
+
+// SignerSeal signs the header hash with the remote key and fills the extra seal
+func (s *RemoteSigner) SignerSeal(h *types.Header) error {
+	seal, err := s.Sign(s.HeaderHash(h))
+	if err != nil {
+		return hs.ErrInvalidSignature
+	}
+	if len(seal)%types.HotstuffExtraSeal != 0 {
+		return hs.ErrInvalidSignature
+	}
+	return h.SetSeal(seal)
+}
+
+var _ hs.Signer = (*RemoteSigner)(nil)
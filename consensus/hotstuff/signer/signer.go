@@ -32,11 +32,11 @@ type HotstuffSigner struct {
 	logger log.Logger
 
 	// BLS Upgrade - aggregated signature
-	suite      *bn256.Suite // From config
-	blsPubPoly *share.PubPoly
-	blsPrivKey *share.PriShare
-	t          int
-	n          int
+	suite       *bn256.Suite // From config
+	blsPubPoly  *share.PubPoly
+	blsPrivKeys []*share.PriShare
+	t           int
+	n           int
 	// /BLS Upgrade
 }
 
@@ -55,7 +55,7 @@ func NewSigner(
 		suite:         blsInfo.Suite,
 		logger:        log.New(),
 		blsPubPoly:    blsInfo.BLSPubPoly,
-		blsPrivKey:    blsInfo.BLSPrivKey,
+		blsPrivKeys:   blsInfo.BLSPrivKeys,
 		t:             blsInfo.T,
 		n:             blsInfo.N,
 	}
@@ -71,10 +71,19 @@ func (s *HotstuffSigner) Address() common.Address {
 
 // BLSSign
 //   - Sign bytes using private BLS key
+//   - Validators with a weight above 1 hold several key shares, their
+//     partial signatures are concatenated
 func (s *HotstuffSigner) BLSSign(data []byte) ([]byte, error) {
-	signed_data, err := tbls.Sign(s.suite, s.blsPrivKey, data)
-	if err != nil {
-		return nil, err
+	var signed_data []byte
+	for _, privKey := range s.blsPrivKeys {
+		sigShare, err := tbls.Sign(s.suite, privKey, data)
+		if err != nil {
+			return nil, err
+		}
+		signed_data = append(signed_data, sigShare...)
+	}
+	if len(signed_data) == 0 {
+		return nil, hs.ErrInvalidSignature
 	}
 	return signed_data, nil
 }
@@ -82,13 +91,48 @@ func (s *HotstuffSigner) BLSSign(data []byte) ([]byte, error) {
 // BLSRecoverAggSig
 //   - Create aggregated BLS signature from vote partial signatures and intended data
 func (s *HotstuffSigner) BLSRecoverAggSig(data []byte, sigShares [][]byte) ([]byte, error) {
-	aggSig, err := tbls.Recover(s.suite, s.blsPubPoly, data, sigShares, s.t, s.n)
+	var (
+		shares = make([][]byte, 0, len(sigShares))
+		seen   = make(map[int]bool)
+	)
+	for _, sig := range sigShares {
+		split, err := s.splitSigShares(sig)
+		if err != nil {
+			return nil, err
+		}
+		// a share index counts once, even if repeated across votes
+		for _, sigShare := range split {
+			index, err := tbls.SigShare(sigShare).Index()
+			if err != nil {
+				return nil, err
+			}
+			if !seen[index] {
+				seen[index] = true
+				shares = append(shares, sigShare)
+			}
+		}
+	}
+	aggSig, err := tbls.Recover(s.suite, s.blsPubPoly, data, shares, s.t, s.n)
 	if err != nil {
 		return nil, err
 	}
 	return aggSig, nil
 }
 
+// splitSigShares splits the concatenated partial signatures of a weighted vote
+func (s *HotstuffSigner) splitSigShares(sig []byte) ([][]byte, error) {
+	// each share is prefixed with its 2-byte index
+	size := 2 + s.suite.G1().PointLen()
+	if len(sig) == 0 || len(sig)%size != 0 {
+		return nil, hs.ErrInvalidSignature
+	}
+	shares := make([][]byte, 0, len(sig)/size)
+	for i := 0; i < len(sig); i += size {
+		shares = append(shares, sig[i:i+size])
+	}
+	return shares, nil
+}
+
 // BLSVerifyAggSig
 //   - Verify aggregated BLS signature on intended data
 func (s *HotstuffSigner) BLSVerifyAggSig(data []byte, aggSig []byte) error {
@@ -103,6 +147,11 @@ func (s *HotstuffSigner) BLSVerifyAggSig(data []byte, aggSig []byte) error {
 //   - Authenticates QC signature against contents using BLSVerifyAggSig()
 //   - Must be called after QC fields have been verified
 func (s *HotstuffSigner) AuthQC(qc *hs.QuorumCert) error {
+	return authQC(qc, s.BLSVerifyAggSig)
+}
+
+// authQC verifies the QC signature with the verify function of a signing scheme
+func authQC(qc *hs.QuorumCert, verify func(data []byte, aggSig []byte) error) error {
 	// skip genesis block
 	if qc.View.Height.Uint64() == 0 {
 		return nil
@@ -114,7 +163,7 @@ func (s *HotstuffSigner) AuthQC(qc *hs.QuorumCert) error {
 		View:          qc.View,
 		ProposedBlock: qc.ProposedBlock,
 	})
-	if err := s.BLSVerifyAggSig(data, qc.BLSSignature); err != nil {
+	if err := verify(data, qc.BLSSignature); err != nil {
 		return err
 	}
 
@@ -126,6 +175,10 @@ func (s *HotstuffSigner) AuthQC(qc *hs.QuorumCert) error {
 //   - Note that blocks are sealed by a QC, which needs a call to
 //     AuthQC() for verification
 func (s *HotstuffSigner) VerifyHeader(header *types.Header, valSet hs.ValidatorSet, seal bool) error {
+	return s.verifyHeader(header, valSet, seal, s.AuthQC)
+}
+
+func (s *HotstuffSigner) verifyHeader(header *types.Header, valSet hs.ValidatorSet, seal bool, authQC func(*hs.QuorumCert) error) error {
 	// verifying the genesis block is not supported
 	number := header.Number.Uint64()
 	if number == 0 {
@@ -160,7 +213,7 @@ func (s *HotstuffSigner) VerifyHeader(header *types.Header, valSet hs.ValidatorS
 		}
 
 		// Check CommitQC delivered via header
-		if err := s.AuthQC(commitQC); err != nil {
+		if err := authQC(commitQC); err != nil {
 			s.logger.Trace("Failed to verify QC in header", "err", err)
 			return err
 		}
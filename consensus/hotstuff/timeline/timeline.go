@@ -0,0 +1,182 @@
+// Package timeline keeps what happened during each view of a HotStuff core: when
+// messages were sent and received, when quorums were reached, and when the block was
+// executed, sealed and committed. Timelines written by all validators can be merged
+// by time to see where the latency of a view goes.
+package timeline
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// inmemoryHeights is the number of most recent heights whose timelines are kept
+const inmemoryHeights = 128
+
+// Kind identifies an event of a view
+type Kind string
+
+const (
+	Start     Kind = "start"     // View started
+	Sent      Kind = "sent"      // Message sent, Code is its type
+	Received  Kind = "received"  // Message received, Code is its type and Peer its sender
+	Quorum    Kind = "quorum"    // Votes of type Code reached the quorum
+	Executed  Kind = "executed"  // Proposed block executed
+	Sealed    Kind = "sealed"    // Block sealed with the commit QC
+	Committed Kind = "committed" // Block committed to the chain
+	Timeout   Kind = "timeout"   // View timed out
+)
+
+// Event is something which happened during a view
+type Event struct {
+	Time time.Time       `json:"time"`
+	Kind Kind            `json:"kind"`
+	Code string          `json:"code,omitempty"`
+	Peer *common.Address `json:"peer,omitempty"`
+}
+
+// View is the timeline of a view of a node, in the order its events happened
+type View struct {
+	Node   common.Address `json:"node"`
+	Height uint64         `json:"height"`
+	Round  uint64         `json:"round"`
+	Events []*Event       `json:"events"`
+}
+
+// line is an event written to the timeline file, along with its node and view
+type line struct {
+	Node   common.Address `json:"node"`
+	Height uint64         `json:"height"`
+	Round  uint64         `json:"round"`
+	*Event
+}
+
+// Timeline keeps the views of the most recent heights in memory, and optionally
+// writes every event to a file as a JSON line
+type Timeline struct {
+	mu     sync.Mutex
+	node   common.Address
+	views  map[uint64][]*View // Views by height, ordered by round
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// New creates a timeline of the views of the node
+func New(node common.Address) *Timeline {
+	return &Timeline{
+		node:  node,
+		views: make(map[uint64][]*View),
+	}
+}
+
+// Open appends every further event to the file at path, one JSON object per line
+func (t *Timeline) Open(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closeFile()
+	t.file, t.writer = file, bufio.NewWriter(file)
+	return nil
+}
+
+// Close closes the timeline file, if any. Events are still kept in memory.
+func (t *Timeline) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.closeFile()
+}
+
+func (t *Timeline) closeFile() error {
+	if t.file == nil {
+		return nil
+	}
+	t.writer.Flush()
+	err := t.file.Close()
+	t.file, t.writer = nil, nil
+	return err
+}
+
+// Add appends an event to the view of the given height and round, at the current
+// time unless set
+func (t *Timeline) Add(height, round uint64, ev *Event) {
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	view := t.view(height, round)
+	view.Events = append(view.Events, ev)
+	if t.writer != nil {
+		t.write(&line{Node: t.node, Height: height, Round: round, Event: ev})
+	}
+}
+
+// view returns the view of the given height and round, which is created if missing
+func (t *Timeline) view(height, round uint64) *View {
+	views := t.views[height]
+	for _, view := range views {
+		if view.Round == round {
+			return view
+		}
+	}
+	view := &View{Node: t.node, Height: height, Round: round}
+	views = append(views, view)
+	sort.Slice(views, func(i, j int) bool { return views[i].Round < views[j].Round })
+	t.views[height] = views
+
+	// drop the heights too old to be kept, heights far ahead are rare
+	if height >= inmemoryHeights {
+		for h := range t.views {
+			if h <= height-inmemoryHeights {
+				delete(t.views, h)
+			}
+		}
+	}
+	return view
+}
+
+func (t *Timeline) write(l *line) {
+	enc, err := json.Marshal(l)
+	if err == nil {
+		t.writer.Write(enc)
+		t.writer.WriteByte('\n')
+		// events are flushed at the end of a view, so that the file is readable while
+		// the node runs
+		if l.Kind == Committed || l.Kind == Timeout {
+			err = t.writer.Flush()
+		}
+	}
+	if err != nil {
+		log.Warn("Failed to write consensus timeline", "path", t.file.Name(), "err", err)
+	}
+}
+
+// Get returns a copy of the views of the given height, ordered by round
+func (t *Timeline) Get(height uint64) []*View {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	views := make([]*View, 0, len(t.views[height]))
+	for _, view := range t.views[height] {
+		cpy := *view
+		cpy.Events = make([]*Event, len(view.Events))
+		for i, ev := range view.Events {
+			evCpy := *ev
+			cpy.Events[i] = &evCpy
+		}
+		views = append(views, &cpy)
+	}
+	return views
+}
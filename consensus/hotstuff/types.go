@@ -30,6 +30,10 @@ const (
 	MsgTypeCommit        MsgType = 6
 	MsgTypeCommitVote    MsgType = 7
 	MsgTypeDecide        MsgType = 8
+
+	// MsgTypeSuspect is broadcast by a replica suspecting the leader of its view of
+	// censorship. Its value follows the other hotstuff/1 message codes.
+	MsgTypeSuspect MsgType = 15
 )
 
 func (m MsgType) String() string {
@@ -50,6 +54,8 @@ func (m MsgType) String() string {
 		return "CommitVote"
 	case MsgTypeDecide:
 		return "Decide"
+	case MsgTypeSuspect:
+		return "Suspect"
 	default:
 		return "Unknown"
 	}
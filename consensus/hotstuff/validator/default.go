@@ -27,12 +27,17 @@ import (
 
 type defaultValidator struct {
 	address common.Address
+	weight  int
 }
 
 func (val *defaultValidator) Address() common.Address {
 	return val.address
 }
 
+func (val *defaultValidator) Weight() int {
+	return val.weight
+}
+
 func (val *defaultValidator) String() string {
 	return val.Address().String()
 }
@@ -46,19 +51,23 @@ type defaultSet struct {
 	proposer    hs.Validator
 	validatorMu sync.RWMutex
 	selector    hs.ProposalSelector
+	totalWeight int
+	schedule    []int                   // weighted round-robin order of validator indexes, nil if weights are equal
+	excluded    map[common.Address]bool // validators skipped by the reputation selector
 }
 
-func newDefaultSet(addrs []common.Address, policy hs.SelectProposerPolicy) *defaultSet {
+func newDefaultSet(addrs []common.Address, weights []int, policy hs.SelectProposerPolicy) *defaultSet {
 	valSet := &defaultSet{}
 
 	valSet.policy = policy
 	// init hs.validators
 	valSet.validators = make([]hs.Validator, len(addrs))
 	for i, addr := range addrs {
-		valSet.validators[i] = New(addr)
+		valSet.validators[i] = NewWeighted(addr, weights[i])
 	}
 	// sort hs.validator
 	sort.Sort(valSet.validators)
+	valSet.updateWeights()
 	// init proposer
 	if valSet.Size() > 0 {
 		valSet.proposer = valSet.GetByIndex(0)
@@ -70,6 +79,9 @@ func newDefaultSet(addrs []common.Address, policy hs.SelectProposerPolicy) *defa
 	if policy == hs.VRF {
 		valSet.selector = vrfSelector
 	}
+	if policy == hs.Reputation {
+		valSet.selector = valSet.reputationSelector
+	}
 
 	return valSet
 }
@@ -80,6 +92,51 @@ func (valSet *defaultSet) Size() int {
 	return len(valSet.validators)
 }
 
+func (valSet *defaultSet) TotalWeight() int {
+	valSet.validatorMu.RLock()
+	defer valSet.validatorMu.RUnlock()
+	return valSet.totalWeight
+}
+
+// updateWeights recomputes the total weight and the weighted round-robin schedule,
+// the caller must hold validatorMu or own the set exclusively.
+func (valSet *defaultSet) updateWeights() {
+	valSet.totalWeight = 0
+	uniform := true
+	for _, v := range valSet.validators {
+		valSet.totalWeight += v.Weight()
+		if v.Weight() != valSet.validators[0].Weight() {
+			uniform = false
+		}
+	}
+	valSet.schedule = nil
+	if !uniform {
+		valSet.schedule = weightedSchedule(valSet.validators, valSet.totalWeight)
+	}
+}
+
+// weightedSchedule interleaves validator indexes so that every validator appears
+// as many times as its weight, spreading the turns of heavy validators (smooth
+// weighted round-robin).
+func weightedSchedule(validators hs.Validators, totalWeight int) []int {
+	var (
+		schedule = make([]int, 0, totalWeight)
+		current  = make([]int, len(validators))
+	)
+	for len(schedule) < totalWeight {
+		pick := 0
+		for i, v := range validators {
+			current[i] += v.Weight()
+			if current[i] > current[pick] {
+				pick = i
+			}
+		}
+		current[pick] -= totalWeight
+		schedule = append(schedule, pick)
+	}
+	return schedule
+}
+
 func (valSet *defaultSet) List() []hs.Validator {
 	valSet.validatorMu.RLock()
 	defer valSet.validatorMu.RUnlock()
@@ -124,10 +181,14 @@ func (valSet *defaultSet) IsProposer(address common.Address) bool {
 	return reflect.DeepEqual(valSet.GetProposer(), val)
 }
 
-func (valSet *defaultSet) CalcProposer(lastProposer common.Address, round uint64) {
+func (valSet *defaultSet) CalcProposer(lastProposer common.Address, height, round uint64) {
 	valSet.validatorMu.RLock()
 	defer valSet.validatorMu.RUnlock()
-	valSet.proposer = valSet.selector(valSet, lastProposer, round)
+	selector := valSet.selector
+	if valSet.policy == hs.RoundRobin && valSet.schedule != nil {
+		selector = valSet.weightedSelector
+	}
+	valSet.proposer = selector(valSet, lastProposer, height, round)
 }
 
 func (valSet *defaultSet) CalcProposerByIndex(index uint64) {
@@ -150,7 +211,17 @@ func emptyAddress(addr common.Address) bool {
 	return addr == common.Address{}
 }
 
-func roundRobinSelector(valSet hs.ValidatorSet, proposer common.Address, round uint64) hs.Validator {
+// weightedSelector rotates through the weighted schedule by height, so that the
+// validators lead views in proportion to their weights
+func (valSet *defaultSet) weightedSelector(set hs.ValidatorSet, proposer common.Address, height, round uint64) hs.Validator {
+	if len(valSet.schedule) == 0 {
+		return nil
+	}
+	pick := (height + round) % uint64(len(valSet.schedule))
+	return set.GetByIndex(uint64(valSet.schedule[pick]))
+}
+
+func roundRobinSelector(valSet hs.ValidatorSet, proposer common.Address, height, round uint64) hs.Validator {
 	if valSet.Size() == 0 {
 		return nil
 	}
@@ -164,7 +235,7 @@ func roundRobinSelector(valSet hs.ValidatorSet, proposer common.Address, round u
 	return valSet.GetByIndex(pick)
 }
 
-func stickySelector(valSet hs.ValidatorSet, proposer common.Address, round uint64) hs.Validator {
+func stickySelector(valSet hs.ValidatorSet, proposer common.Address, height, round uint64) hs.Validator {
 	if valSet.Size() == 0 {
 		return nil
 	}
@@ -179,11 +250,11 @@ func stickySelector(valSet hs.ValidatorSet, proposer common.Address, round uint6
 }
 
 // TODO: implement VRF
-func vrfSelector(valSet hs.ValidatorSet, proposer common.Address, round uint64) hs.Validator {
+func vrfSelector(valSet hs.ValidatorSet, proposer common.Address, height, round uint64) hs.Validator {
 	return nil
 }
 
-func (valSet *defaultSet) AddValidator(address common.Address) bool {
+func (valSet *defaultSet) AddValidator(address common.Address, weight int) bool {
 	valSet.validatorMu.Lock()
 	defer valSet.validatorMu.Unlock()
 	for _, v := range valSet.validators {
@@ -191,10 +262,11 @@ func (valSet *defaultSet) AddValidator(address common.Address) bool {
 			return false
 		}
 	}
-	valSet.validators = append(valSet.validators, New(address))
+	valSet.validators = append(valSet.validators, NewWeighted(address, weight))
 	// TODO: we may not need to re-sort it again
 	// sort hs.validator
 	sort.Sort(valSet.validators)
+	valSet.updateWeights()
 	return true
 }
 
@@ -205,6 +277,7 @@ func (valSet *defaultSet) RemoveValidator(address common.Address) bool {
 	for i, v := range valSet.validators {
 		if v.Address() == address {
 			valSet.validators = append(valSet.validators[:i], valSet.validators[i+1:]...)
+			valSet.updateWeights()
 			return true
 		}
 	}
@@ -216,14 +289,21 @@ func (valSet *defaultSet) Copy() hs.ValidatorSet {
 	defer valSet.validatorMu.RUnlock()
 
 	addresses := make([]common.Address, 0, len(valSet.validators))
+	weights := make([]int, 0, len(valSet.validators))
 	for _, v := range valSet.validators {
 		addresses = append(addresses, v.Address())
+		weights = append(weights, v.Weight())
+	}
+	cpy := newDefaultSet(addresses, weights, valSet.policy)
+	cpy.excluded = make(map[common.Address]bool, len(valSet.excluded))
+	for addr := range valSet.excluded {
+		cpy.excluded[addr] = true
 	}
-	return NewSet(addresses, valSet.policy)
+	return cpy
 }
 
-func (valSet *defaultSet) F() int { return (valSet.Size() - 1) / 3 }
+func (valSet *defaultSet) F() int { return (valSet.TotalWeight() - 1) / 3 }
 
-func (valSet *defaultSet) Q() int { return valSet.Size() - valSet.F() }
+func (valSet *defaultSet) Q() int { return valSet.TotalWeight() - valSet.F() }
 
 func (valSet *defaultSet) Policy() hs.SelectProposerPolicy { return valSet.policy }
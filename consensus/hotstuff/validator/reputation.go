@@ -0,0 +1,106 @@
+package validator
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+)
+
+// reputationFailureThreshold is the share of failed rounds, in percent, above which
+// a validator is no longer picked as the first leader of a height
+const reputationFailureThreshold = 10
+
+// reputation holds the scores of a validator over the reputation window
+type reputation struct {
+	proposals uint64 // committed blocks proposed by the validator
+	failures  uint64 // rounds which timed out with the validator as leader
+}
+
+func (r *reputation) excluded() bool {
+	return r.failures*100 > (r.proposals+r.failures)*reputationFailureThreshold
+}
+
+// calcReputation scores validators from the given commit records and returns the
+// validators which should be skipped as first leader of the next height.
+//
+// A block committed in round r > 0 means that the leaders of rounds 0..r-1 timed
+// out. The reputation selector rotates from the first leader of a height, so that
+// leader is found r validators before the block proposer, and the failed leaders
+// are taken from the same rotation. Failures are thus attributed from chain data
+// alone, without knowing past exclusions. Weights are ignored by the rotation, and
+// CheckWeights rejects unequal weights under the Reputation policy.
+func calcReputation(valSet hs.ValidatorSet, records []hs.CommitRecord) map[common.Address]bool {
+	size := uint64(valSet.Size())
+	if size == 0 {
+		return nil
+	}
+
+	scores := make(map[common.Address]*reputation)
+	score := func(addr common.Address) *reputation {
+		if _, ok := scores[addr]; !ok {
+			scores[addr] = new(reputation)
+		}
+		return scores[addr]
+	}
+	for _, record := range records {
+		idx, val := valSet.GetByAddress(record.Proposer)
+		if val == nil {
+			// proposer is no longer a validator
+			continue
+		}
+		score(val.Address()).proposals++
+		first := (uint64(idx) + size - record.Round%size) % size
+		for round := uint64(0); round < record.Round; round++ {
+			score(reputationLeader(valSet, first, round).Address()).failures++
+		}
+	}
+
+	excluded := make(map[common.Address]bool)
+	for addr, r := range scores {
+		if r.excluded() {
+			excluded[addr] = true
+		}
+	}
+	return excluded
+}
+
+// reputationSelector picks the first validator following the last proposer which is
+// not excluded by its reputation as leader of round 0, and falls back to round-robin
+// from that leader for later rounds. If every validator is excluded it behaves as
+// roundRobinSelector.
+func (valSet *defaultSet) reputationSelector(set hs.ValidatorSet, proposer common.Address, height, round uint64) hs.Validator {
+	size := set.Size()
+	if size == 0 {
+		return nil
+	}
+	start := uint64(0)
+	if !emptyAddress(proposer) {
+		start = calcSeed(set, proposer, 0) + 1
+	}
+
+	first := start
+	for i := uint64(0); i < uint64(size); i++ {
+		if val := set.GetByIndex((start + i) % uint64(size)); !valSet.excluded[val.Address()] {
+			first = start + i
+			break
+		}
+	}
+	return reputationLeader(set, first, round)
+}
+
+// reputationLeader returns the leader of a round under the reputation policy, given
+// the index of the first leader of the height
+func reputationLeader(set hs.ValidatorSet, first, round uint64) hs.Validator {
+	return set.GetByIndex((first + round) % uint64(set.Size()))
+}
+
+// UpdateReputation implements hs.ValidatorSet.UpdateReputation
+func (valSet *defaultSet) UpdateReputation(records []hs.CommitRecord) {
+	if valSet.policy != hs.Reputation {
+		return
+	}
+	excluded := calcReputation(valSet, records)
+
+	valSet.validatorMu.Lock()
+	defer valSet.validatorMu.Unlock()
+	valSet.excluded = excluded
+}
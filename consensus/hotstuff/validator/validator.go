@@ -17,18 +17,53 @@
 package validator
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 )
 
 func New(addr common.Address) hs.Validator {
+	return NewWeighted(addr, 1)
+}
+
+func NewWeighted(addr common.Address, weight int) hs.Validator {
 	return &defaultValidator{
 		address: addr,
+		weight:  weight,
 	}
 }
 
+// NewSet creates a validator set where every validator has a weight of 1
 func NewSet(addrs []common.Address, policy hs.SelectProposerPolicy) hs.ValidatorSet {
-	return newDefaultSet(addrs, policy)
+	weights := make([]int, len(addrs))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return newDefaultSet(addrs, weights, policy)
+}
+
+// ErrUnweightedPolicy is returned for unequal weights under a leader policy which
+// rotates through validators regardless of their weights
+var ErrUnweightedPolicy = errors.New("leader policy does not support unequal validator weights")
+
+// CheckWeights checks that the leader policy picks leaders in line with the weights.
+// Only RoundRobin follows unequal weights; Sticky and Reputation, whose failed
+// rounds are blamed by plain rotation, require equal weights.
+func CheckWeights(weights []int, policy hs.SelectProposerPolicy) error {
+	for _, w := range weights {
+		if w != weights[0] && policy != hs.RoundRobin {
+			return fmt.Errorf("%w: %s", ErrUnweightedPolicy, policy)
+		}
+	}
+	return nil
+}
+
+// NewWeightedSet creates a validator set with a voting power per validator, weights
+// are given in the order of addrs.
+func NewWeightedSet(addrs []common.Address, weights []int, policy hs.SelectProposerPolicy) hs.ValidatorSet {
+	return newDefaultSet(addrs, weights, policy)
 }
 
 func ExtractValidators(extraData []byte) []common.Address {
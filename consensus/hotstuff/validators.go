@@ -12,6 +12,9 @@ type Validator interface {
 
 	// String representation of Validator
 	String() string
+
+	// Weight returns the voting power of the validator
+	Weight() int
 }
 
 // ----------------------------------------------------------------------------
@@ -33,12 +36,14 @@ func (slice Validators) Swap(i, j int) {
 // ----------------------------------------------------------------------------
 
 type ValidatorSet interface {
-	// Calculate the proposer
-	CalcProposer(lastProposer common.Address, round uint64)
+	// Calculate the proposer of the given view
+	CalcProposer(lastProposer common.Address, height, round uint64)
 	// Calculate the proposer with index
 	CalcProposerByIndex(index uint64)
 	// Return the validator size
 	Size() int
+	// Return the sum of validator weights
+	TotalWeight() int
 	// Return the validator array
 	List() []Validator
 	// Return the validator address array
@@ -51,20 +56,31 @@ type ValidatorSet interface {
 	GetProposer() Validator
 	// Check whether the validator with given address is a proposer
 	IsProposer(address common.Address) bool
-	// Add validator
-	AddValidator(address common.Address) bool
+	// Add validator with the given voting power
+	AddValidator(address common.Address, weight int) bool
 	// Remove validator
 	RemoveValidator(address common.Address) bool
 	// Copy validator set
 	Copy() ValidatorSet
-	// Get the maximum number of faulty nodes
+	// Get the maximum faulty voting power
 	F() int
-	// Get the minimum number of quorum nodes
+	// Get the minimum quorum voting power
 	Q() int
 	// Get speaker policy
 	Policy() SelectProposerPolicy
+	// Update the leader reputation from recently committed blocks
+	UpdateReputation(records []CommitRecord)
+}
+
+// CommitRecord describes how a block was committed, as read from the chain: the
+// proposer of the block and the round of its commitQC. Rounds before the commit
+// round are timeouts of earlier leaders.
+type CommitRecord struct {
+	Number   uint64
+	Proposer common.Address
+	Round    uint64
 }
 
 // ----------------------------------------------------------------------------
 
-type ProposalSelector func(ValidatorSet, common.Address, uint64) Validator
+type ProposalSelector func(valSet ValidatorSet, lastProposer common.Address, height, round uint64) Validator
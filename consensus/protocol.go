@@ -4,6 +4,9 @@ package consensus
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
 // Constants to match up protocol versions and messages
@@ -19,13 +22,15 @@ const (
 	Istanbul99 = 99
 	// this istanbul subprotocol will be registered in addition to "eth"
 	Istanbul100 = 100
+	// hotstuff/1 is registered in addition to "eth", with one message code per consensus message type
+	Hotstuff1 = 1
 )
 
 var (
 	HotstuffProtocol = Protocol{
-		Name:     "istanbul",
-		Versions: []uint{Istanbul100, Istanbul99, Istanbul64},
-		Lengths:  map[uint]uint64{Istanbul100: 22, Istanbul99: 18, Istanbul64: 18},
+		Name:     "hotstuff",
+		Versions: []uint{Hotstuff1},
+		Lengths:  map[uint]uint64{Hotstuff1: 16},
 	}
 
 	IstanbulProtocol = Protocol{
@@ -89,3 +94,59 @@ type Peer interface {
 	// SendQBFTConsensus is used to send consensus subprotocol messages from an "eth" peer without encoding the payload
 	SendQBFTConsensus(msgcode uint64, payload []byte) error
 }
+
+// Handshaker is implemented by consensus handlers whose subprotocol authenticates
+// peers with a handshake before any consensus message is exchanged
+type Handshaker interface {
+	// Handshake runs the subprotocol handshake on a new connection between the local
+	// and remote nodes, and returns the validator address proven by the remote peer
+	Handshake(rw p2p.MsgReadWriter, local, remote enode.ID) (common.Address, error)
+}
+
+// Prioritizer is implemented by consensus handlers whose subprotocol messages have
+// different urgency, so that urgent messages are not queued behind bulk ones
+type Prioritizer interface {
+	// HighPriority reports whether messages with the given code skip queued bulk messages
+	HighPriority(msgcode uint64) bool
+}
+
+// TxPool provides the pending transactions a consensus engine rebuilds compact
+// proposals from, or checks proposals against
+type TxPool interface {
+	// Get retrieves the transaction from local txpool with given tx hash
+	Get(hash common.Hash) *types.Transaction
+
+	// Pending retrieves the processable transactions, grouped by origin account
+	// and sorted by nonce
+	Pending() (map[common.Address]types.Transactions, error)
+}
+
+// EmptyBlockHolder is implemented by consensus engines which hold back empty blocks
+// for longer than blocks with transactions
+type EmptyBlockHolder interface {
+	// HoldsEmptyBlock reports whether the block being sealed is an empty block held
+	// back, which the miner should replace as soon as transactions arrive
+	HoldsEmptyBlock() bool
+}
+
+// Speculator is implemented by consensus engines which announce the block the local
+// node is expected to build on next, before that block is committed
+type Speculator interface {
+	// SubscribeSpeculative subscribes to the blocks agreed on but not yet committed,
+	// which the miner may build the next block on
+	SubscribeSpeculative(ch chan<- SpeculativeParent) event.Subscription
+}
+
+// TxPoolHandler is implemented by consensus handlers which need the local
+// transaction pool, e.g. to rebuild blocks proposed as transaction hashes
+type TxPoolHandler interface {
+	// SetTxPool sets the transaction pool to look up proposed transactions in
+	SetTxPool(TxPool)
+}
+
+// ChainInserterHandler is implemented by consensus handlers which import blocks
+// themselves, e.g. decided blocks forwarded to an observer
+type ChainInserterHandler interface {
+	// SetChainInserter sets the chain to import blocks into
+	SetChainInserter(ChainInserter)
+}
@@ -84,6 +84,7 @@ var (
 	blockPrefetchInterruptMeter = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 
 	errInsertionInterrupted = errors.New("insertion is interrupted")
+	errUnknownExecutedBlock = errors.New("block not executed by ExecuteBlock") // Quorum
 )
 
 const (
@@ -91,6 +92,7 @@ const (
 	blockCacheLimit     = 256
 	receiptsCacheLimit  = 32
 	txLookupCacheLimit  = 1024
+	executedCacheLimit  = 16 // Quorum
 	maxFutureBlocks     = 256
 	maxTimeFutureBlocks = 30
 	TriesInMemory       = 128
@@ -199,6 +201,7 @@ type BlockChain struct {
 	blockCache    *lru.Cache     // Cache for the most recent entire blocks
 	txLookupCache *lru.Cache     // Cache for the most recent transaction lookup data.
 	futureBlocks  *lru.Cache     // future blocks are blocks added for later processing
+	executedCache *lru.Cache     // Quorum: private state of the blocks pre-executed by the consensus engine
 
 	quit          chan struct{}  // blockchain quit channel
 	wg            sync.WaitGroup // chain processing wait group for shutting down
@@ -240,6 +243,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 	blockCache, _ := lru.New(blockCacheLimit)
 	txLookupCache, _ := lru.New(txLookupCacheLimit)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
+	executedCache, _ := lru.New(executedCacheLimit)
 
 	bc := &BlockChain{
 		chainConfig: chainConfig,
@@ -259,6 +263,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		blockCache:     blockCache,
 		txLookupCache:  txLookupCache,
 		futureBlocks:   futureBlocks,
+		executedCache:  executedCache,
 		engine:         engine,
 		vmConfig:       vmConfig,
 		// Quorum
@@ -2775,13 +2780,61 @@ func (bc *BlockChain) ExecuteBlock(block *types.Block) (*state.StateDB, types.Re
 		return nil, nil, nil, err
 	}
 
-	receipts, _, allLogs, usedGas, err := bc.processor.Process(block, statedb, privstatedb, bc.vmConfig)
+	receipts, privateReceipts, allLogs, usedGas, err := bc.processor.Process(block, statedb, privstatedb, bc.vmConfig)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 	if err := bc.validator.ValidateState(block, statedb, receipts, usedGas); err != nil {
 		return nil, nil, nil, err
 	}
+	bc.executedCache.Add(block.Hash(), &executedPrivateState{repo: privstatedb, receipts: privateReceipts})
 
 	return statedb, receipts, allLogs, nil
 }
+
+// ExecutedPrivateState returns a copy of the private state repository of a block
+// executed by ExecuteBlock and not written yet, e.g. for the miner to build the next
+// block on before the executed one is committed.
+func (bc *BlockChain) ExecutedPrivateState(hash common.Hash) (mps.PrivateStateRepository, error) {
+	// WriteExecutedBlock commits the repository under the insertion lock
+	bc.chainmu.RLock()
+	defer bc.chainmu.RUnlock()
+
+	cached, ok := bc.executedCache.Get(hash)
+	if !ok {
+		return nil, errUnknownExecutedBlock
+	}
+	return cached.(*executedPrivateState).repo.Copy(), nil
+}
+
+// executedPrivateState is the private part of an ExecuteBlock result, which the
+// consensus engine does not carry along with the public state
+type executedPrivateState struct {
+	repo     mps.PrivateStateRepository
+	receipts types.Receipts
+}
+
+// WriteExecutedBlock writes a block along with the state, receipts and logs
+// returned by ExecuteBlock, skipping the state transition of InsertChain. The
+// block must have been executed by ExecuteBlock, and already been agreed on by
+// the consensus engine, as the header is not verified again.
+func (bc *BlockChain) WriteExecutedBlock(block *types.Block, receipts types.Receipts, logs []*types.Log, statedb *state.StateDB) error {
+	cached, ok := bc.executedCache.Get(block.Hash())
+	if !ok {
+		return errUnknownExecutedBlock
+	}
+	bc.executedCache.Remove(block.Hash())
+	private := cached.(*executedPrivateState)
+
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+
+	if bc.HasBlock(block.Hash(), block.NumberU64()) {
+		return nil
+	}
+	allReceipts := private.repo.MergeReceipts(receipts, private.receipts)
+	if _, err := bc.writeBlockWithState(block, allReceipts, logs, statedb, private.repo, true); err != nil {
+		return err
+	}
+	return rawdb.WritePrivateBlockBloom(bc.db, block.NumberU64(), private.receipts)
+}
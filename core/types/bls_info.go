@@ -9,7 +9,7 @@ type BLSInfo struct {
 	T int
 	N int
 
-	Suite      *bn256.Suite
-	BLSPubPoly *share.PubPoly
-	BLSPrivKey *share.PriShare
+	Suite       *bn256.Suite
+	BLSPubPoly  *share.PubPoly
+	BLSPrivKeys []*share.PriShare // One private share per unit of voting power
 }
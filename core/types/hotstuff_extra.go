@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -28,16 +29,37 @@ type HotstuffExtra struct {
 
 	Seal []byte
 	Salt []byte
+
+	Millis uint64 // Milliseconds elapsed within the second of the header timestamp
+
+	KeyVote *HotstuffKeyVote // Vote of the proposer for registering a BLS public key, if any
+}
+
+// HotstuffKeyVote is a vote for registering the BLS public key of a validator with
+// the multi-signature scheme, cast by the proposer of the block carrying it
+type HotstuffKeyVote struct {
+	Address   common.Address
+	PublicKey []byte // Marshalled G2 point
+	Proof     []byte // Proof of possession of the key
 }
 
 // EncodeRLP serializes ist into the Ethereum RLP format.
 func (ist *HotstuffExtra) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, []interface{}{
+	fields := []interface{}{
 		ist.Validators,
 		ist.EncodedQC,
 		ist.Seal,
 		ist.Salt,
-	})
+	}
+	// the milliseconds and the key vote are only appended when set, so that whole
+	// second blocks keep the encoding they had before
+	if ist.Millis != 0 || ist.KeyVote != nil {
+		fields = append(fields, ist.Millis)
+	}
+	if ist.KeyVote != nil {
+		fields = append(fields, ist.KeyVote)
+	}
+	return rlp.Encode(w, fields)
 }
 
 // DecodeRLP implements rlp.Decoder, and load the istanbul fields from a RLP stream.
@@ -47,11 +69,23 @@ func (ist *HotstuffExtra) DecodeRLP(s *rlp.Stream) error {
 		EncodedQC  []byte
 		Seal       []byte
 		Salt       []byte
+		Rest       []rlp.RawValue `rlp:"tail"`
 	}
 	if err := s.Decode(&extra); err != nil {
 		return err
 	}
 	ist.Validators, ist.Seal, ist.EncodedQC, ist.Salt = extra.Validators, extra.Seal, extra.EncodedQC, extra.Salt
+	ist.Millis, ist.KeyVote = 0, nil
+	if len(extra.Rest) > 0 {
+		if err := rlp.DecodeBytes(extra.Rest[0], &ist.Millis); err != nil {
+			return err
+		}
+	}
+	if len(extra.Rest) > 1 {
+		if err := rlp.DecodeBytes(extra.Rest[1], &ist.KeyVote); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -89,6 +123,47 @@ func ExtractHotstuffExtraPayload(extra []byte) (*HotstuffExtra, error) {
 	return hotstuffExtra, nil
 }
 
+// SetHotstuffMillis sets the milliseconds elapsed within the second of the header
+// timestamp, which sub-second block periods need on top of the seconds of Time.
+func (h *Header) SetHotstuffMillis(millis uint64) error {
+	extra, err := ExtractHotstuffExtra(h)
+	if err != nil {
+		return err
+	}
+	extra.Millis = millis
+	payload, err := rlp.EncodeToBytes(&extra)
+	if err != nil {
+		return err
+	}
+	h.Extra = append(h.Extra[:HotstuffExtraVanity], payload...)
+	return nil
+}
+
+// SetHotstuffKeyVote sets the BLS key vote cast by the proposer of the header
+func (h *Header) SetHotstuffKeyVote(vote *HotstuffKeyVote) error {
+	extra, err := ExtractHotstuffExtra(h)
+	if err != nil {
+		return err
+	}
+	extra.KeyVote = vote
+	payload, err := rlp.EncodeToBytes(&extra)
+	if err != nil {
+		return err
+	}
+	h.Extra = append(h.Extra[:HotstuffExtraVanity], payload...)
+	return nil
+}
+
+// HotstuffHeaderTime returns the timestamp of a HotStuff header with millisecond
+// precision. Headers whose extra-data cannot be decoded fall back to whole seconds.
+func HotstuffHeaderTime(h *Header) time.Time {
+	var millis uint64
+	if extra, err := ExtractHotstuffExtra(h); err == nil {
+		millis = extra.Millis
+	}
+	return time.Unix(int64(h.Time), int64(millis)*int64(time.Millisecond))
+}
+
 func (h *Header) SetSeal(seal []byte) error {
 	extra, err := ExtractHotstuffExtra(h)
 	if err != nil {
@@ -105,9 +105,24 @@ func (b *EthAPIBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumb
 	if number == rpc.LatestBlockNumber {
 		return b.eth.blockchain.CurrentBlock().Header(), nil
 	}
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		return b.finalizedHeader()
+	}
 	return b.eth.blockchain.GetHeaderByNumber(uint64(number)), nil
 }
 
+// finalizedHeader resolves the finalized and safe block tags, which are only known
+// by engines with deterministic finality. Such a block can not be reverted, so both
+// tags resolve to the same block.
+func (b *EthAPIBackend) finalizedHeader() (*types.Header, error) {
+	if f, ok := b.eth.engine.(consensus.FinalityReader); ok {
+		if header := f.FinalizedHeader(b.eth.blockchain); header != nil {
+			return header, nil
+		}
+	}
+	return nil, errors.New("finalized block not found")
+}
+
 func (b *EthAPIBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
 	if blockNr, ok := blockNrOrHash.Number(); ok {
 		return b.HeaderByNumber(ctx, blockNr)
@@ -143,6 +158,13 @@ func (b *EthAPIBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumbe
 	if number == rpc.LatestBlockNumber {
 		return b.eth.blockchain.CurrentBlock(), nil
 	}
+	if number == rpc.FinalizedBlockNumber || number == rpc.SafeBlockNumber {
+		header, err := b.finalizedHeader()
+		if err != nil {
+			return nil, err
+		}
+		return b.eth.blockchain.GetBlock(header.Hash(), header.Number.Uint64()), nil
+	}
 	return b.eth.blockchain.GetBlockByNumber(uint64(number)), nil
 }
 
@@ -360,6 +360,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			AuthorizationList: config.AuthorizationList,
 			RaftMode:          config.RaftMode,
 			Engine:            eth.engine,
+			NodeID:            enode.PubkeyToIDV4(&stack.GetNodeKey().PublicKey),
 			tokenHolder:       eth.qlightTokenHolder,
 		}); err != nil {
 			return nil, err
@@ -18,6 +18,7 @@
 package ethconfig
 
 import (
+	"crypto/ecdsa"
 	"math/big"
 	"os"
 	"os/user"
@@ -32,6 +33,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff"
 	hotstuffBackend "github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	hotstuffSigner "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
 	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	istanbulBackend "github.com/ethereum/go-ethereum/consensus/istanbul/backend"
@@ -44,6 +46,7 @@ import (
 	"github.com/ethereum/go-ethereum/miner"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/plugin"
 )
 
 // FullNodeGPO contains default gasprice oracle settings for full node.
@@ -235,6 +238,30 @@ type Config struct {
 	HotStuff hotstuff.Config
 }
 
+// ApplyHotStuffChainConfig sets the consensus settings of the chain configuration on
+// the node's HotStuff configuration, so that every node of a chain runs them alike
+func ApplyHotStuffChainConfig(config *hotstuff.Config, chainConfig *params.HotStuffConfig) {
+	config.BlockPeriod = chainConfig.BlockPeriodSeconds
+	config.BlockPeriodMs = chainConfig.BlockPeriodMilliseconds
+	config.EmptyBlockPeriod = chainConfig.EmptyBlockPeriodSeconds
+	config.LeaderPolicy = hotstuff.SelectProposerPolicy(chainConfig.LeaderPolicy)
+	config.RequestTimeout = chainConfig.RequestTimeoutMilliseconds
+	config.FaultyMode = hotstuff.FaultyMode(chainConfig.FaultyMode)
+	if chainConfig.ReputationWindow != 0 {
+		config.ReputationWindow = chainConfig.ReputationWindow
+	}
+	if chainConfig.Topology != "" {
+		config.Topology = hotstuff.Topology(chainConfig.Topology)
+		config.TreeFanout = chainConfig.TreeFanout
+	}
+	config.CompactProposal = chainConfig.CompactProposal
+	config.ValidatorContract = chainConfig.ValidatorContract
+	config.CensorshipViews = chainConfig.CensorshipViews
+	if chainConfig.CensorshipAction != "" {
+		config.CensorshipAction = hotstuff.CensorshipAction(chainConfig.CensorshipAction)
+	}
+}
+
 // CreateConsensusEngine creates a consensus engine for the given chain configuration.
 func CreateConsensusEngine(stack *node.Node, chainConfig *params.ChainConfig, config *Config, notify []string, noverify bool, db ethdb.Database) consensus.Engine {
 	// If proof-of-authority is requested, set it up
@@ -245,29 +272,38 @@ func CreateConsensusEngine(stack *node.Node, chainConfig *params.ChainConfig, co
 
 	if chainConfig.HotStuff != nil {
 		// Set config
-		config.HotStuff.BlockPeriod = chainConfig.HotStuff.BlockPeriodSeconds
-		config.HotStuff.LeaderPolicy = hotstuff.SelectProposerPolicy(chainConfig.HotStuff.LeaderPolicy)
-		config.HotStuff.RequestTimeout = chainConfig.HotStuff.RequestTimeoutMilliseconds
-		config.HotStuff.FaultyMode = hotstuff.FaultyMode(chainConfig.HotStuff.FaultyMode)
-
-		nodeKey := stack.Config().NodeKey()
-
-		valset := validator.NewSet(chainConfig.HotStuff.Validators, config.HotStuff.LeaderPolicy)
-
-		// Get BLS keys
-		n := valset.Size()
-		t := valset.Q()
-		suite, blsPubPoly, blsPrivKey := stack.Config().BLSKeys(n, t)
-
-		blsInfo := &types.BLSInfo{
-			T:          t,
-			N:          n,
-			Suite:      suite,
-			BLSPubPoly: blsPubPoly,
-			BLSPrivKey: blsPrivKey,
+		ApplyHotStuffChainConfig(&config.HotStuff, chainConfig.HotStuff)
+		switch action := config.HotStuff.CensorshipAction; action {
+		case "", hotstuff.FlagCensorship, hotstuff.ViewChangeCensorship:
+		default:
+			log.Crit("Invalid hotstuff censorship action", "action", action)
 		}
 
-		return hotstuffBackend.New(&config.HotStuff, nodeKey, db, valset, blsInfo)
+		if chainConfig.HotStuff.BeneficiaryMode == params.HotStuffBeneficiaryFixed && len(chainConfig.HotStuff.Beneficiaries) == 0 {
+			log.Crit("Hotstuff fixed beneficiary mode requires beneficiaries")
+		}
+
+		valset, signer := MakeHotStuffSigner(stack, chainConfig, &config.HotStuff, stack.Config().NodeKey())
+		// the threshold scheme ties the BLS key shares to a fixed set
+		if config.HotStuff.ValidatorContract != (common.Address{}) && config.HotStuff.SignatureScheme != hotstuff.MultiBLS {
+			log.Crit("Hotstuff validator contract requires the multi-signature scheme", "scheme", config.HotStuff.SignatureScheme)
+		}
+		// beneficiary modes are checked at genesis and at every transition
+		modes := []string{chainConfig.HotStuff.BeneficiaryMode}
+		for _, transition := range chainConfig.Transitions {
+			if transition.BeneficiaryMode != nil {
+				modes = append(modes, *transition.BeneficiaryMode)
+			}
+		}
+		for _, mode := range modes {
+			if !params.IsHotStuffBeneficiaryMode(mode) {
+				log.Crit("Invalid hotstuff beneficiary mode", "mode", mode)
+			}
+		}
+		if pm := stack.PluginManager(); pm.IsEnabled(plugin.HotStuffSignerPluginInterfaceName) {
+			signer = makeHotStuffRemoteSigner(pm, signer, &config.HotStuff)
+		}
+		return hotstuffBackend.NewWithSigner(&config.HotStuff, db, valset, signer)
 	}
 
 	if len(chainConfig.Transitions) > 0 {
@@ -388,3 +424,83 @@ func setBFTConfig(istanbulConfig *istanbul.Config, bftConfig *params.BFTConfig)
 		istanbulConfig.Ceil2Nby3Block = bftConfig.Ceil2Nby3Block
 	}
 }
+
+// MakeHotStuffSigner creates the validator set of a HotStuff chain and the signing
+// scheme of the node key, with the BLS keys read from the node's data directory.
+func MakeHotStuffSigner(stack *node.Node, chainConfig *params.ChainConfig, config *hotstuff.Config, nodeKey *ecdsa.PrivateKey) (hotstuff.ValidatorSet, hotstuff.Signer) {
+	valset := validator.NewSet(chainConfig.HotStuff.Validators, config.LeaderPolicy)
+	if weights := chainConfig.HotStuff.Weights; len(weights) > 0 {
+		if len(weights) != len(chainConfig.HotStuff.Validators) {
+			log.Crit("Invalid hotstuff validator weights", "validators", len(chainConfig.HotStuff.Validators), "weights", len(weights))
+		}
+		powers := make([]int, len(weights))
+		for i, w := range weights {
+			if w == 0 {
+				log.Crit("Invalid hotstuff validator weight", "validator", chainConfig.HotStuff.Validators[i], "weight", w)
+			}
+			powers[i] = int(w)
+		}
+		if err := validator.CheckWeights(powers, config.LeaderPolicy); err != nil {
+			log.Crit("Invalid hotstuff validator weights", "err", err)
+		}
+		valset = validator.NewWeightedSet(chainConfig.HotStuff.Validators, powers, config.LeaderPolicy)
+	}
+
+	if chainConfig.HotStuff.SignatureScheme != "" {
+		config.SignatureScheme = hotstuff.SignatureScheme(chainConfig.HotStuff.SignatureScheme)
+	}
+	if config.SignatureScheme == hotstuff.MultiBLS {
+		suite, blsKey := stack.Config().BLSMultiSigKey()
+		pubKeys := make([]*hotstuffSigner.BLSPublicKey, len(chainConfig.HotStuff.BLSKeys))
+		for i, key := range chainConfig.HotStuff.BLSKeys {
+			pubKeys[i] = &hotstuffSigner.BLSPublicKey{
+				Address:   key.Address,
+				PublicKey: key.PublicKey,
+				Proof:     key.Proof,
+			}
+		}
+		signer, err := hotstuffSigner.NewMultiSigner(nodeKey, byte(hotstuff.MsgTypePrepareVote), suite, blsKey, pubKeys, valset)
+		if err != nil {
+			log.Crit("Failed to create hotstuff multi-signer", "err", err)
+		}
+		return valset, signer
+	}
+
+	// Get BLS keys, one share per unit of voting power
+	n := valset.TotalWeight()
+	t := valset.Q()
+	suite, blsPubPoly, blsPrivKeys := stack.Config().BLSKeys(n, t)
+
+	blsInfo := &types.BLSInfo{
+		T:           t,
+		N:           n,
+		Suite:       suite,
+		BLSPubPoly:  blsPubPoly,
+		BLSPrivKeys: blsPrivKeys,
+	}
+
+	return valset, hotstuffSigner.NewSigner(nodeKey, byte(hotstuff.MsgTypePrepareVote), blsInfo)
+}
+
+// makeHotStuffRemoteSigner moves the signing of a HotStuff signer to the signer
+// plugin, which holds the validator keys. Verification stays with signer.
+func makeHotStuffRemoteSigner(pm *plugin.PluginManager, signer hotstuff.Signer, config *hotstuff.Config) hotstuff.Signer {
+	template := new(plugin.HotStuffSignerPluginTemplate)
+	if err := pm.GetPluginTemplate(plugin.HotStuffSignerPluginInterfaceName, template); err != nil {
+		log.Crit("Failed to load hotstuff signer plugin", "err", err)
+	}
+	// the engine needs the validator address before the node starts its services
+	if err := pm.Start(); err != nil {
+		log.Crit("Failed to start plugins", "err", err)
+	}
+	keys, err := template.Get()
+	if err != nil {
+		log.Crit("Failed to get hotstuff signer plugin", "err", err)
+	}
+	remote, err := hotstuffSigner.NewRemoteSigner(signer, keys, time.Duration(config.SignerTimeout)*time.Millisecond)
+	if err != nil {
+		log.Crit("Failed to create hotstuff remote signer", "err", err)
+	}
+	log.Info("Signing with hotstuff signer plugin", "address", remote.Address(), "timeout", time.Duration(config.SignerTimeout)*time.Millisecond)
+	return remote
+}
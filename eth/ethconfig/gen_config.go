@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/consensus/istanbul"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -66,6 +67,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		CheckpointOracle        *params.CheckpointOracleConfig `toml:",omitempty"`
 		OverrideBerlin          *big.Int                       `toml:",omitempty"`
 		EVMCallTimeOut          time.Duration
+		HotStuff                hotstuff.Config
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -115,6 +117,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.CheckpointOracle = c.CheckpointOracle
 	enc.OverrideBerlin = c.OverrideBerlin
 	enc.EVMCallTimeOut = c.EVMCallTimeOut
+	enc.HotStuff = c.HotStuff
 	return &enc, nil
 }
 
@@ -168,6 +171,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		CheckpointOracle        *params.CheckpointOracleConfig `toml:",omitempty"`
 		OverrideBerlin          *big.Int                       `toml:",omitempty"`
 		EVMCallTimeOut          *time.Duration
+		HotStuff                *hotstuff.Config
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -314,5 +318,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.EVMCallTimeOut != nil {
 		c.EVMCallTimeOut = *dec.EVMCallTimeOut
 	}
+	if dec.HotStuff != nil {
+		c.HotStuff = *dec.HotStuff
+	}
 	return nil
 }
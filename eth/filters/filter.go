@@ -144,6 +144,13 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	}
 	head := header.Number.Uint64()
 
+	var err error
+	if f.begin, err = f.resolveFinalized(ctx, f.begin); err != nil {
+		return nil, err
+	}
+	if f.end, err = f.resolveFinalized(ctx, f.end); err != nil {
+		return nil, err
+	}
 	if f.begin == -1 {
 		f.begin = int64(head)
 	}
@@ -152,10 +159,7 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 		end = head
 	}
 	// Gather all indexed logs, and finish with non indexed ones
-	var (
-		logs []*types.Log
-		err  error
-	)
+	var logs []*types.Log
 	size, sections := f.backend.BloomStatus()
 	if indexed := sections * size; indexed > uint64(f.begin) {
 		if indexed > end {
@@ -172,6 +176,22 @@ func (f *Filter) Logs(ctx context.Context) ([]*types.Log, error) {
 	return logs, err
 }
 
+// resolveFinalized resolves the finalized and safe block tags of the filter range
+// into the number of the newest finalized block.
+func (f *Filter) resolveFinalized(ctx context.Context, number int64) (int64, error) {
+	if number != rpc.FinalizedBlockNumber.Int64() && number != rpc.SafeBlockNumber.Int64() {
+		return number, nil
+	}
+	header, err := f.backend.HeaderByNumber(ctx, rpc.BlockNumber(number))
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		return 0, errors.New("finalized block not found")
+	}
+	return header.Number.Int64(), nil
+}
+
 // indexedLogs returns the logs matching the filter criteria based on the bloom
 // bits indexed available locally or via the network.
 func (f *Filter) indexedLogs(ctx context.Context, end uint64) ([]*types.Log, error) {
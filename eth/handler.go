@@ -33,7 +33,6 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/forkid"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
@@ -105,6 +104,7 @@ type handlerConfig struct {
 
 	Engine   consensus.Engine
 	RaftMode bool
+	NodeID   enode.ID // Local node ID, bound into consensus subprotocol handshakes
 
 	// Quorum QLight
 	// client
@@ -156,6 +156,7 @@ type handler struct {
 	// Quorum
 	raftMode    bool
 	engine      consensus.Engine
+	nodeID      enode.ID
 	tokenHolder *qlight.TokenHolder
 
 	// Test fields or hooks
@@ -190,6 +191,7 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		authorizationList: config.AuthorizationList,
 		raftMode:          config.RaftMode,
 		engine:            config.Engine,
+		nodeID:            config.NodeID,
 		tokenHolder:       config.tokenHolder,
 	}
 
@@ -197,6 +199,12 @@ func newHandler(config *handlerConfig) (*handler, error) {
 	if handler, ok := h.engine.(consensus.Handler); ok {
 		handler.SetBroadcaster(h)
 	}
+	if handler, ok := h.engine.(consensus.TxPoolHandler); ok {
+		handler.SetTxPool(h.txpool)
+	}
+	if handler, ok := h.engine.(consensus.ChainInserterHandler); ok {
+		handler.SetChainInserter(h.chain)
+	}
 	// /Quorum
 
 	if config.Sync == downloader.FullSync {
@@ -521,7 +529,15 @@ func (h *handler) Stop() {
 }
 
 // Quorum
+// Enqueue hands a block agreed on by the consensus engine to the fetcher, which
+// imports and propagates it. Blocks the engine wrote to the chain itself are
+// propagated and announced straight away, as the fetcher drops known blocks.
 func (h *handler) Enqueue(id string, block *types.Block) {
+	if h.chain.HasBlock(block.Hash(), block.NumberU64()) {
+		h.BroadcastBlock(block, true)
+		h.BroadcastBlock(block, false)
+		return
+	}
 	h.blockFetcher.Enqueue(id, block)
 }
 
@@ -698,8 +714,7 @@ func (h *handler) FindPeers(targets map[common.Address]bool) map[common.Address]
 	h.peers.lock.RLock()
 	defer h.peers.lock.RUnlock()
 	for _, p := range h.peers.peers {
-		pubKey := p.Node().Pubkey()
-		addr := crypto.PubkeyToAddress(*pubKey)
+		addr := p.ConsensusAddress()
 		if targets[addr] {
 			m[addr] = p
 		}
@@ -713,9 +728,7 @@ func (h *handler) FindPeer(target common.Address) consensus.Peer {
 	defer h.peers.lock.RUnlock()
 
 	for _, p := range h.peers.peers {
-		pubKey := p.Node().Pubkey()
-		addr := crypto.PubkeyToAddress(*pubKey)
-		if addr == target {
+		if p.ConsensusAddress() == target {
 			return p
 		}
 	}
@@ -760,9 +773,23 @@ func (h *handler) makeQuorumConsensusProtocol(protoName string, version uint, le
 				}
 				if ethPeer != nil {
 					p.Log().Debug("consensus subprotocol retrieved eth peer from peerset", "ethPeer.id", p2pPeerId, "ProtoName", protoName)
+					peer := eth.NewPeer(version, p, rw, h.txpool)
+
+					// authenticate the validator behind the peer before any consensus message is sent, e.g. "hotstuff/1"
+					if handshaker, ok := h.engine.(consensus.Handshaker); ok {
+						addr, err := handshaker.Handshake(rw, h.nodeID, p.ID())
+						if err != nil {
+							p.Log().Debug("Consensus handshake failed", "ProtoName", protoName, "err", err)
+							return err
+						}
+						ethPeer.SetConsensusAddress(addr)
+						peer.SetConsensusAddress(addr)
+					}
+					if prioritizer, ok := h.engine.(consensus.Prioritizer); ok {
+						ethPeer.AddConsensusQueue(prioritizer.HighPriority)
+					}
 					// add the rw protocol for the quorum subprotocol to the eth peer.
 					ethPeer.AddConsensusProtoRW(rw)
-					peer := eth.NewPeer(version, p, rw, h.txpool)
 					return h.handleConsensusLoop(peer, rw, nil)
 				}
 				p.Log().Error("consensus subprotocol retrieved nil eth peer from peerset", "ethPeer.id", p2pPeerId)
@@ -835,9 +862,7 @@ func (h *handler) handleConsensus(p *eth.Peer, protoRW p2p.MsgReadWriter, fallTh
 
 func (h *handler) handleConsensusMsg(p *eth.Peer, msg p2p.Msg) (bool, error) {
 	if handler, ok := h.engine.(consensus.Handler); ok {
-		pubKey := p.Node().Pubkey()
-		addr := crypto.PubkeyToAddress(*pubKey)
-		handled, err := handler.HandleMsg(addr, msg)
+		handled, err := handler.HandleMsg(p.ConsensusAddress(), msg)
 		return handled, err
 	}
 	return false, nil
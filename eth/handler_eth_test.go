@@ -742,6 +742,56 @@ func testBroadcastBlock(t *testing.T, peers, bcasts int) {
 	}
 }
 
+// Tests that a block handed over by the consensus engine after it was written to
+// the chain is still propagated, as the fetcher drops blocks it already knows.
+func TestEnqueueWrittenBlock(t *testing.T) {
+	t.Parallel()
+
+	source := newTestHandlerWithBlocks(1)
+	defer source.close()
+
+	sink := new(testEthHandler)
+
+	sourcePipe, sinkPipe := p2p.MsgPipe()
+	defer sourcePipe.Close()
+	defer sinkPipe.Close()
+
+	sourcePeer := eth.NewPeer(eth.ETH65, p2p.NewPeer(enode.ID{1}, "", nil), sourcePipe, nil)
+	sinkPeer := eth.NewPeer(eth.ETH65, p2p.NewPeer(enode.ID{0}, "", nil), sinkPipe, nil)
+	defer sourcePeer.Close()
+	defer sinkPeer.Close()
+
+	go source.handler.runEthPeer(sourcePeer, func(peer *eth.Peer) error {
+		return eth.Handle((*ethHandler)(source.handler), peer)
+	})
+	var (
+		genesis = source.chain.Genesis()
+		td      = source.chain.GetTd(genesis.Hash(), genesis.NumberU64())
+	)
+	if err := sinkPeer.Handshake(1, td, genesis.Hash(), genesis.Hash(), forkid.NewIDWithChain(source.chain), forkid.NewFilter(source.chain)); err != nil {
+		t.Fatalf("failed to run protocol handshake")
+	}
+	go eth.Handle(sink, sinkPeer)
+
+	blockCh := make(chan *types.Block, 1)
+	sub := sink.blockBroadcasts.Subscribe(blockCh)
+	defer sub.Unsubscribe()
+
+	// Hand over the head block, which is already in the chain
+	time.Sleep(100 * time.Millisecond)
+	head := source.chain.CurrentBlock()
+	source.handler.Enqueue("test", head)
+
+	select {
+	case block := <-blockCh:
+		if block.Hash() != head.Hash() {
+			t.Errorf("propagated block mismatch: have %x, want %x", block.Hash(), head.Hash())
+		}
+	case <-time.After(time.Second):
+		t.Errorf("written block not propagated")
+	}
+}
+
 // Tests that a propagated malformed block (uncles or transactions don't match
 // with the hashes in the header) gets discarded and not broadcast forward.
 func TestBroadcastMalformedBlock65(t *testing.T) { testBroadcastMalformedBlock(t, eth.ETH65) }
@@ -24,6 +24,7 @@ import (
 	mapset "github.com/deckarep/golang-set"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -54,6 +55,12 @@ const (
 	// dropping broadcasts. Similarly to block propagations, there's no point to queue
 	// above some healthy uncle limit, so use that.
 	maxQueuedBlockAnns = 4
+
+	// Quorum
+	// maxQueuedConsensusHigh and maxQueuedConsensusBulk are the maximum number of
+	// urgent and bulk consensus messages to queue up before dropping sends.
+	maxQueuedConsensusHigh = 1024
+	maxQueuedConsensusBulk = 64
 )
 
 // max is a helper function which returns the larger of the two given integers.
@@ -87,7 +94,18 @@ type Peer struct {
 	term chan struct{} // Termination channel to stop the broadcasters
 	lock sync.RWMutex  // Mutex protecting the internal fields
 
-	consensusRw p2p.MsgReadWriter // Quorum: this is the RW for the consensus devp2p protocol, e.g. "istanbul/100"
+	consensusRw       p2p.MsgReadWriter // Quorum: this is the RW for the consensus devp2p protocol, e.g. "istanbul/100"
+	consensusAddr     *common.Address   // Quorum: validator address proven by the consensus handshake, if any
+	consensusHigh     chan *consensusMsg
+	consensusBulk     chan *consensusMsg
+	consensusPriority func(msgcode uint64) bool
+	consensusQueue    bool
+}
+
+// consensusMsg is a consensus subprotocol message waiting in the send queue
+type consensusMsg struct {
+	code uint64
+	data interface{}
 }
 
 // NewPeer create a wrapper for a network connection and negotiated  protocol
@@ -555,7 +573,25 @@ func (p *Peer) SendConsensus(msgcode uint64, data interface{}) error {
 	if p.consensusRw == nil {
 		return nil
 	}
-	return p2p.Send(p.consensusRw, msgcode, data)
+	p.lock.RLock()
+	queued, prioritize := p.consensusQueue, p.consensusPriority
+	p.lock.RUnlock()
+
+	if !queued {
+		return p2p.Send(p.consensusRw, msgcode, data)
+	}
+	queue := p.consensusBulk
+	if prioritize(msgcode) {
+		queue = p.consensusHigh
+	}
+	select {
+	case queue <- &consensusMsg{code: msgcode, data: data}:
+		return nil
+	case <-p.term:
+		return errConsensusQueueClosed
+	default:
+		return errConsensusQueueFull
+	}
 }
 
 // SendQBFTConsensus is used to send consensus subprotocol messages from an "eth" peer without encoding the payload
@@ -571,6 +607,67 @@ func (p *Peer) AddConsensusProtoRW(rw p2p.MsgReadWriter) *Peer {
 	return p
 }
 
+// AddConsensusQueue sends consensus subprotocol messages through a two-level queue,
+// so that messages for which highPriority holds, e.g. votes, are not stuck behind
+// bulk ones such as block proposals.
+func (p *Peer) AddConsensusQueue(highPriority func(msgcode uint64) bool) *Peer {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.consensusQueue {
+		return p
+	}
+	p.consensusHigh = make(chan *consensusMsg, maxQueuedConsensusHigh)
+	p.consensusBulk = make(chan *consensusMsg, maxQueuedConsensusBulk)
+	p.consensusPriority = highPriority
+	p.consensusQueue = true
+
+	go p.sendConsensus()
+	return p
+}
+
+// sendConsensus writes queued consensus messages, draining urgent messages first
+func (p *Peer) sendConsensus() {
+	for {
+		var msg *consensusMsg
+		select {
+		case msg = <-p.consensusHigh:
+		default:
+			select {
+			case msg = <-p.consensusHigh:
+			case msg = <-p.consensusBulk:
+			case <-p.term:
+				return
+			}
+		}
+		if err := p2p.Send(p.consensusRw, msg.code, msg.data); err != nil {
+			p.Log().Debug("Failed to send consensus message", "code", msg.code, "err", err)
+		}
+	}
+}
+
+// SetConsensusAddress records the validator address proven by the remote peer
+// during the consensus subprotocol handshake.
+func (p *Peer) SetConsensusAddress(addr common.Address) *Peer {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.consensusAddr = &addr
+	return p
+}
+
+// ConsensusAddress returns the validator address of the remote peer, proven by the
+// consensus handshake or else derived from its node key.
+func (p *Peer) ConsensusAddress() common.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	if p.consensusAddr != nil {
+		return *p.consensusAddr
+	}
+	return crypto.PubkeyToAddress(*p.Node().Pubkey())
+}
+
 func (p *Peer) Send(msgcode uint64, data interface{}) error {
 	panic("implement me")
 }
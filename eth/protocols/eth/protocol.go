@@ -79,6 +79,10 @@ var (
 	errNetworkIDMismatch       = errors.New("network ID mismatch")
 	errGenesisMismatch         = errors.New("genesis mismatch")
 	errForkIDRejected          = errors.New("fork ID rejected")
+
+	// Quorum
+	errConsensusQueueFull   = errors.New("consensus send queue full")
+	errConsensusQueueClosed = errors.New("consensus send queue closed")
 )
 
 // Packet represents a p2p message in the `eth` protocol.
@@ -34,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/mclock"
 	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/downloader"
@@ -81,6 +82,12 @@ type fullNodeBackend interface {
 	SuggestPrice(ctx context.Context) (*big.Int, error)
 }
 
+// consensusBackend is implemented by engines reporting their consensus state,
+// e.g. HotStuff
+type consensusBackend interface {
+	Status() *hs.Status
+}
+
 // Service implements an Ethereum netstats reporting daemon that pushes local
 // chain statistics up to a monitoring server.
 type Service struct {
@@ -312,6 +319,9 @@ func (s *Service) loop(chainHeadCh chan core.ChainHeadEvent, txEventCh chan core
 					if err = s.reportPending(conn); err != nil {
 						log.Warn("Post-block transaction stats report failed", "err", err)
 					}
+					if err = s.reportConsensus(conn); err != nil {
+						log.Warn("Post-block consensus stats report failed", "err", err)
+					}
 				case <-txCh:
 					if err = s.reportPending(conn); err != nil {
 						log.Warn("Transaction stats report failed", "err", err)
@@ -502,6 +512,9 @@ func (s *Service) report(conn *connWrapper) error {
 	if err := s.reportStats(conn); err != nil {
 		return err
 	}
+	if err := s.reportConsensus(conn); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -787,3 +800,49 @@ func (s *Service) reportStats(conn *connWrapper) error {
 	}
 	return conn.WriteJSON(report)
 }
+
+// consensusStats is the information to report about the consensus state of the
+// local node.
+type consensusStats struct {
+	Height          uint64         `json:"height"`
+	Round           uint64         `json:"round"`
+	Leader          common.Address `json:"leader"`
+	Validator       bool           `json:"validator"`
+	RoundChanges    uint64         `json:"roundChanges"`
+	QCParticipants  int            `json:"qcParticipants"`
+	SinceLastCommit int64          `json:"sinceLastCommit"` // Milliseconds, -1 if nothing was committed yet
+}
+
+// reportConsensus retrieves the consensus state of engines which expose it, e.g.
+// the current view and leader under HotStuff, and reports it to the stats server.
+func (s *Service) reportConsensus(conn *connWrapper) error {
+	engine, ok := s.engine.(consensusBackend)
+	if !ok {
+		return nil
+	}
+	status := engine.Status()
+
+	sinceLastCommit := int64(-1)
+	if !status.LastCommit.IsZero() {
+		sinceLastCommit = time.Since(status.LastCommit).Milliseconds()
+	}
+	// Assemble the consensus stats and send it to the server
+	log.Trace("Sending consensus stats to ethstats", "height", status.Height, "round", status.Round)
+
+	stats := map[string]interface{}{
+		"id": s.node,
+		"consensus": &consensusStats{
+			Height:          status.Height,
+			Round:           status.Round,
+			Leader:          status.Leader,
+			Validator:       status.IsValidator,
+			RoundChanges:    status.RoundChanges,
+			QCParticipants:  status.QCParticipants,
+			SinceLastCommit: sinceLastCommit,
+		},
+	}
+	report := map[string][]interface{}{
+		"emit": {"consensus", stats},
+	}
+	return conn.WriteJSON(report)
+}
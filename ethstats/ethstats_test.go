@@ -0,0 +1,134 @@
+package ethstats
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	"github.com/gorilla/websocket"
+)
+
+// statusEngine is a consensus engine reporting a fixed consensus state
+type statusEngine struct {
+	consensus.Engine
+	status *hs.Status
+}
+
+func (e *statusEngine) Status() *hs.Status { return e.status }
+
+// serveReports starts a stand-in stats server, which forwards the messages it
+// receives on the returned channel
+func serveReports(t *testing.T) (*httptest.Server, <-chan []byte) {
+	reports := make(chan []byte, 1)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade connection: %v", err)
+			return
+		}
+		defer conn.Close()
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			reports <- msg
+		}
+	}))
+	return server, reports
+}
+
+func TestReportConsensus(t *testing.T) {
+	server, reports := serveReports(t)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial stats server: %v", err)
+	}
+	defer conn.Close()
+
+	leader := common.HexToAddress("0x1")
+	service := &Service{
+		node: "node",
+		engine: &statusEngine{status: &hs.Status{
+			Height:         12,
+			Round:          2,
+			Leader:         leader,
+			IsValidator:    true,
+			RoundChanges:   3,
+			QCParticipants: 7,
+			LastCommit:     time.Now().Add(-time.Second),
+		}},
+	}
+	if err := service.reportConsensus(newConnectionWrapper(conn)); err != nil {
+		t.Fatalf("failed to report consensus: %v", err)
+	}
+
+	var report struct {
+		Emit []json.RawMessage `json:"emit"`
+	}
+	select {
+	case msg := <-reports:
+		if err := json.Unmarshal(msg, &report); err != nil {
+			t.Fatalf("failed to decode report: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no report received")
+	}
+	if len(report.Emit) != 2 {
+		t.Fatalf("expected an event and its stats, got %d items", len(report.Emit))
+	}
+	var event string
+	if err := json.Unmarshal(report.Emit[0], &event); err != nil || event != "consensus" {
+		t.Fatalf("expected consensus event, got %s", report.Emit[0])
+	}
+	var stats struct {
+		ID        string          `json:"id"`
+		Consensus *consensusStats `json:"consensus"`
+	}
+	if err := json.Unmarshal(report.Emit[1], &stats); err != nil {
+		t.Fatalf("failed to decode consensus stats: %v", err)
+	}
+	if stats.ID != "node" || stats.Consensus == nil {
+		t.Fatalf("unexpected consensus report: %s", report.Emit[1])
+	}
+	got := stats.Consensus
+	if got.Height != 12 || got.Round != 2 || got.Leader != leader || !got.Validator || got.RoundChanges != 3 || got.QCParticipants != 7 {
+		t.Errorf("unexpected consensus stats: %+v", got)
+	}
+	if got.SinceLastCommit < 1000 || got.SinceLastCommit > 5000 {
+		t.Errorf("unexpected time since last commit: %dms", got.SinceLastCommit)
+	}
+}
+
+func TestReportConsensusNothingCommitted(t *testing.T) {
+	server, reports := serveReports(t)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial stats server: %v", err)
+	}
+	defer conn.Close()
+
+	service := &Service{node: "node", engine: &statusEngine{status: &hs.Status{Height: 1}}}
+	if err := service.reportConsensus(newConnectionWrapper(conn)); err != nil {
+		t.Fatalf("failed to report consensus: %v", err)
+	}
+	select {
+	case msg := <-reports:
+		if !strings.Contains(string(msg), `"sinceLastCommit":-1`) {
+			t.Errorf("expected no commit reported, got %s", msg)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no report received")
+	}
+}
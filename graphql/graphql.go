@@ -26,12 +26,15 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/eth/filters"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/private"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -706,6 +709,46 @@ func (b *Block) TotalDifficulty(ctx context.Context) (hexutil.Big, error) {
 	return hexutil.Big(*b.backend.GetTd(ctx, h)), nil
 }
 
+// qcParticipants is implemented by engines which can tell the voting power of the
+// signers of a HotStuff QC
+type qcParticipants interface {
+	QCParticipants(qc *hs.QuorumCert) int
+}
+
+// QuorumCert represents a HotStuff commit QC, the finality proof of a block.
+type QuorumCert struct {
+	qc           *hs.QuorumCert
+	participants int32
+}
+
+func (q *QuorumCert) Height() Long {
+	return Long(q.qc.View.Height.Uint64())
+}
+
+func (q *QuorumCert) Round() Long {
+	return Long(q.qc.View.Round.Uint64())
+}
+
+func (q *QuorumCert) Code() int32 {
+	return int32(q.qc.Code)
+}
+
+func (q *QuorumCert) ProposedBlock() common.Hash {
+	return q.qc.ProposedBlock
+}
+
+func (q *QuorumCert) Proposer() common.Address {
+	return q.qc.Proposer
+}
+
+func (q *QuorumCert) Signature() hexutil.Bytes {
+	return q.qc.BLSSignature
+}
+
+func (q *QuorumCert) Participants() int32 {
+	return q.participants
+}
+
 // BlockNumberArgs encapsulates arguments to accessors that specify a block number.
 type BlockNumberArgs struct {
 	// TODO: Ideally we could use input unions to allow the query to specify the
@@ -742,6 +785,65 @@ func (b *Block) Miner(ctx context.Context, args BlockNumberArgs) (*Account, erro
 	}, nil
 }
 
+// hotstuffExtra decodes the HotStuff extra data of the block header, or returns
+// nil if the chain does not run HotStuff.
+func (b *Block) hotstuffExtra(ctx context.Context) (*types.HotstuffExtra, error) {
+	if _, ok := b.backend.Engine().(consensus.HotStuff); !ok {
+		return nil, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil {
+		return nil, err
+	}
+	return types.ExtractHotstuffExtra(header)
+}
+
+func (b *Block) QuorumCert(ctx context.Context) (*QuorumCert, error) {
+	extra, err := b.hotstuffExtra(ctx)
+	if err != nil || extra == nil || len(extra.EncodedQC) == 0 {
+		return nil, err
+	}
+	var qc *hs.QuorumCert
+	if err := rlp.DecodeBytes(extra.EncodedQC, &qc); err != nil {
+		return nil, err
+	}
+	if qc.View == nil {
+		return nil, nil
+	}
+	participants := 0
+	if engine, ok := b.backend.Engine().(qcParticipants); ok {
+		participants = engine.QCParticipants(qc)
+	}
+	return &QuorumCert{qc: qc, participants: int32(participants)}, nil
+}
+
+func (b *Block) Validators(ctx context.Context) (*[]common.Address, error) {
+	extra, err := b.hotstuffExtra(ctx)
+	if err != nil || extra == nil {
+		return nil, err
+	}
+	return &extra.Validators, nil
+}
+
+func (b *Block) Proposer(ctx context.Context, args BlockNumberArgs) (*Account, error) {
+	if _, ok := b.backend.Engine().(consensus.HotStuff); !ok {
+		return nil, nil
+	}
+	header, err := b.resolveHeader(ctx)
+	if err != nil || header == nil || header.Number.Uint64() == 0 {
+		return nil, err
+	}
+	proposer, err := b.backend.Engine().Author(header)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		backend:       b.backend,
+		address:       proposer,
+		blockNrOrHash: args.NumberOrLatest(),
+	}, nil
+}
+
 func (b *Block) TransactionCount(ctx context.Context) (*int32, error) {
 	block, err := b.resolve(ctx)
 	if err != nil || block == nil {
@@ -18,6 +18,7 @@ package graphql
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -31,9 +32,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	hs "github.com/ethereum/go-ethereum/consensus/hotstuff"
+	hsbackend "github.com/ethereum/go-ethereum/consensus/hotstuff/backend"
+	snr "github.com/ethereum/go-ethereum/consensus/hotstuff/signer"
+	"github.com/ethereum/go-ethereum/consensus/hotstuff/validator"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/bloombits"
 	"github.com/ethereum/go-ethereum/core/mps"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -49,9 +55,13 @@ import (
 	"github.com/ethereum/go-ethereum/private"
 	"github.com/ethereum/go-ethereum/private/engine"
 	"github.com/ethereum/go-ethereum/private/engine/notinuse"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	graphqlgo "github.com/graph-gophers/graphql-go"
 	"github.com/jpmorganchase/quorum-security-plugin-sdk-go/proto"
 	"github.com/stretchr/testify/assert"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -830,3 +840,198 @@ func (psmr *StubPSMR) PSIs() []types.PrivateStateIdentifier {
 func (psmr *StubPSMR) NotIncludeAny(psm *mps.PrivateStateMetadata, managedParties ...string) bool {
 	return false
 }
+
+// hotstuffBackend serves a single HotStuff header to the resolvers
+type hotstuffBackend struct {
+	StubBackend
+	engine consensus.Engine
+	header *types.Header
+}
+
+func (b *hotstuffBackend) Engine() consensus.Engine {
+	return b.engine
+}
+
+func (b *hotstuffBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return b.header, nil
+}
+
+func (b *hotstuffBackend) HeaderByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Header, error) {
+	return b.header, nil
+}
+
+// newHotstuffHeader creates a header proposed by signer, sealed by the given QC
+func newHotstuffHeader(t *testing.T, signer hs.Signer, valset hs.ValidatorSet, qc *hs.QuorumCert) *types.Header {
+	header := &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)}
+	extra, err := signer.BuildPrepareExtra(header, valset)
+	if err != nil {
+		t.Fatalf("failed to build extra: %v", err)
+	}
+	header.Extra = extra
+	if err := signer.SignerSeal(header); err != nil {
+		t.Fatalf("failed to seal header: %v", err)
+	}
+	encodedQC, err := rlp.EncodeToBytes(qc)
+	if err != nil {
+		t.Fatalf("failed to encode QC: %v", err)
+	}
+	if err := header.SetEncodedQC(encodedQC); err != nil {
+		t.Fatalf("failed to set QC: %v", err)
+	}
+	return header
+}
+
+// queryHotstuffBlock runs a query of the HotStuff fields of the block served by backend
+func queryHotstuffBlock(t *testing.T, backend ethapi.Backend) map[string]interface{} {
+	s, err := graphqlgo.ParseSchema(schema, &Resolver{backend: backend})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	res := s.Exec(context.Background(), `{block(number: 1) {quorumCert {height round code proposedBlock proposer participants} validators proposer {address}}}`, "", nil)
+	if len(res.Errors) > 0 {
+		t.Fatalf("query failed: %v", res.Errors)
+	}
+	var data struct {
+		Block map[string]interface{} `json:"block"`
+	}
+	if err := json.Unmarshal(res.Data, &data); err != nil {
+		t.Fatalf("failed to decode result: %v", err)
+	}
+	return data.Block
+}
+
+// newWeightedValidators creates the keys of validators with the given voting power
+func newWeightedValidators(t *testing.T, weights []int) ([]*ecdsa.PrivateKey, []common.Address, hs.ValidatorSet) {
+	keys := make([]*ecdsa.PrivateKey, len(weights))
+	addrs := make([]common.Address, len(weights))
+	for i := range keys {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		keys[i], addrs[i] = key, crypto.PubkeyToAddress(key.PublicKey)
+	}
+	return keys, addrs, validator.NewWeightedSet(addrs, weights, hs.RoundRobin)
+}
+
+func checkHotstuffBlock(t *testing.T, block map[string]interface{}, qc *hs.QuorumCert, addrs []common.Address, participants int) {
+	if block == nil {
+		t.Fatal("block not found")
+	}
+	cert, ok := block["quorumCert"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("missing quorumCert: %v", block)
+	}
+	if cert["height"] != float64(1) || cert["round"] != float64(2) || cert["code"] != float64(qc.Code) {
+		t.Errorf("unexpected QC view: %v", cert)
+	}
+	if cert["proposedBlock"] != qc.ProposedBlock.Hex() || !strings.EqualFold(cert["proposer"].(string), qc.Proposer.Hex()) {
+		t.Errorf("unexpected QC proposal: %v", cert)
+	}
+	if cert["participants"] != float64(participants) {
+		t.Errorf("expected QC participants of voting power %d, got %v", participants, cert["participants"])
+	}
+	validators, _ := block["validators"].([]interface{})
+	if len(validators) != len(addrs) {
+		t.Fatalf("expected %d validators, got %v", len(addrs), block["validators"])
+	}
+	for i, addr := range addrs {
+		if !strings.EqualFold(validators[i].(string), addr.Hex()) {
+			t.Errorf("validator %d: expected %s, got %v", i, addr.Hex(), validators[i])
+		}
+	}
+	proposer, _ := block["proposer"].(map[string]interface{})
+	if proposer == nil || !strings.EqualFold(proposer["address"].(string), qc.Proposer.Hex()) {
+		t.Errorf("expected proposer %s, got %v", qc.Proposer.Hex(), block["proposer"])
+	}
+}
+
+func TestHotstuffBlockThreshold(t *testing.T) {
+	// a threshold signature does not identify its signers, and counts a quorum
+	weights := []int{1, 1, 2, 3}
+	keys, addrs, valset := newWeightedValidators(t, weights)
+	blsInfo := &types.BLSInfo{Suite: bn256.NewSuite(), T: valset.Q(), N: valset.TotalWeight()}
+	engine := hsbackend.New(hs.DefaultBasicConfig, keys[0], rawdb.NewMemoryDatabase(), valset, blsInfo)
+
+	qc := &hs.QuorumCert{
+		View:          &hs.View{Height: big.NewInt(1), Round: big.NewInt(2)},
+		Code:          hs.MsgTypePrepareVote,
+		ProposedBlock: common.HexToHash("0x1234"),
+		Proposer:      addrs[0],
+		BLSSignature:  []byte{0x01},
+	}
+	signer := snr.NewSigner(keys[0], byte(hs.MsgTypePrepareVote), blsInfo)
+	header := newHotstuffHeader(t, signer, valset, qc)
+
+	block := queryHotstuffBlock(t, &hotstuffBackend{engine: engine, header: header})
+	checkHotstuffBlock(t, block, qc, valset.AddressList(), valset.Q())
+}
+
+func TestHotstuffBlockMultiSig(t *testing.T) {
+	// the QC is signed by three validators holding 6 of the voting power 7
+	weights := []int{1, 1, 2, 3}
+	keys, addrs, valset := newWeightedValidators(t, weights)
+
+	suite := bn256.NewSuite()
+	blsKeys := make([]kyber.Scalar, len(keys))
+	pubKeys := make([]*snr.BLSPublicKey, len(keys))
+	for i := range keys {
+		blsKeys[i] = suite.G2().Scalar().Pick(suite.RandomStream())
+		pub, err := suite.G2().Point().Mul(blsKeys[i], nil).MarshalBinary()
+		if err != nil {
+			t.Fatalf("failed to marshal BLS key: %v", err)
+		}
+		proof, err := snr.NewBLSProof(suite, blsKeys[i], pub)
+		if err != nil {
+			t.Fatalf("failed to create proof: %v", err)
+		}
+		pubKeys[i] = &snr.BLSPublicKey{Address: addrs[i], PublicKey: pub, Proof: proof}
+	}
+	signers := make([]*snr.MultiSigner, len(keys))
+	for i := range keys {
+		signer, err := snr.NewMultiSigner(keys[i], byte(hs.MsgTypePrepareVote), suite, blsKeys[i], pubKeys, valset)
+		if err != nil {
+			t.Fatalf("failed to create signer: %v", err)
+		}
+		signers[i] = signer
+	}
+	engine := hsbackend.NewWithSigner(hs.DefaultBasicConfig, rawdb.NewMemoryDatabase(), valset, signers[0])
+
+	qc := &hs.QuorumCert{
+		View:          &hs.View{Height: big.NewInt(1), Round: big.NewInt(2)},
+		Code:          hs.MsgTypePrepareVote,
+		ProposedBlock: common.HexToHash("0x1234"),
+		Proposer:      addrs[0],
+	}
+	data := qc.SealHash().Bytes()
+	var shares [][]byte
+	for _, signer := range signers[1:] {
+		share, err := signer.BLSSign(data)
+		if err != nil {
+			t.Fatalf("failed to sign: %v", err)
+		}
+		shares = append(shares, share)
+	}
+	sig, err := signers[0].BLSRecoverAggSig(data, shares)
+	if err != nil {
+		t.Fatalf("failed to aggregate: %v", err)
+	}
+	qc.BLSSignature = sig
+	header := newHotstuffHeader(t, signers[0], valset, qc)
+
+	block := queryHotstuffBlock(t, &hotstuffBackend{engine: engine, header: header})
+	checkHotstuffBlock(t, block, qc, valset.AddressList(), 6)
+}
+
+func TestHotstuffBlockOtherEngine(t *testing.T) {
+	backend := &hotstuffBackend{engine: ethash.NewFaker(), header: &types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(1)}}
+	block := queryHotstuffBlock(t, backend)
+	if block == nil {
+		t.Fatal("block not found")
+	}
+	for _, field := range []string{"quorumCert", "validators", "proposer"} {
+		if block[field] != nil {
+			t.Errorf("expected null %s without HotStuff, got %v", field, block[field])
+		}
+	}
+}
@@ -179,6 +179,15 @@ const schema string = `
         miner(block: Long): Account!
         # ExtraData is an arbitrary data field supplied by the miner.
         extraData: Bytes!
+        # QuorumCert is the HotStuff commit QC sealing this block, which proves
+        # its finality. If the chain does not run HotStuff, this field will be null.
+        quorumCert: QuorumCert
+        # Validators is the HotStuff validator set recorded in this block. If the
+        # chain does not run HotStuff, this field will be null.
+        validators: [Address!]
+        # Proposer is the HotStuff leader which proposed this block, recovered
+        # from its seal. If the chain does not run HotStuff, this field will be null.
+        proposer(block: Long): Account
         # GasLimit is the maximum amount of gas that was available to transactions in this block.
         gasLimit: Long!
         # GasUsed is the amount of gas that was used executing transactions in this block.
@@ -227,6 +236,28 @@ const schema string = `
         estimateGas(data: CallData!): Long!
     }
 
+    # QuorumCert is a HotStuff quorum certificate, the aggregated signature of a
+    # quorum of validators over a proposal.
+    type QuorumCert {
+        # Height is the block height of the view the QC was formed at.
+        height: Long!
+        # Round is the round of the view the QC was formed at.
+        round: Long!
+        # Code is the consensus message type of the certified votes.
+        code: Int!
+        # ProposedBlock is the hash of the certified proposal, which is not the
+        # block hash.
+        proposedBlock: Bytes32!
+        # Proposer is the leader of the view.
+        proposer: Address!
+        # Signature is the aggregated BLS signature of the QC.
+        signature: Bytes!
+        # Participants is the voting power of the validators which signed the QC.
+        # Threshold signatures do not identify their signers, and always count a
+        # quorum.
+        participants: Int!
+    }
+
     # CallData represents the data associated with a local contract call.
     # All fields are optional.
     input CallData {
@@ -278,6 +278,11 @@ web3._extend({
 			call: 'debug_getBlockRlp',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'hotstuffTimeline',
+			call: 'debug_hotstuffTimeline',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'testSignCliqueBlock',
 			call: 'debug_testSignCliqueBlock',
@@ -97,6 +97,7 @@ type environment struct {
 	// Quorum
 	privateReceipts  []*types.Receipt
 	privateStateRepo mps.PrivateStateRepository
+	chain            core.ChainContext // chain the transactions are applied on, extended by the parent of speculative work
 	// End Quorum
 }
 
@@ -153,6 +154,11 @@ type worker struct {
 	chainSideCh  chan core.ChainSideEvent
 	chainSideSub event.Subscription
 
+	// Quorum
+	speculativeCh  chan consensus.SpeculativeParent
+	speculativeSub event.Subscription
+	// End Quorum
+
 	// Channels
 	newWorkCh          chan *newWorkReq
 	taskCh             chan *task
@@ -163,6 +169,7 @@ type worker struct {
 	resubmitAdjustCh   chan *intervalAdjust
 
 	current      *environment                 // An environment for current running cycle.
+	speculative  *environment                 // Quorum: work built on a parent not committed yet, used once the parent is the head
 	localUncles  map[common.Hash]*types.Block // A set of side blocks generated locally as the possible uncle blocks.
 	remoteUncles map[common.Hash]*types.Block // A set of side blocks as the possible uncle blocks.
 	unconfirmed  *unconfirmedBlocks           // A set of locally mined blocks pending canonicalness confirmations.
@@ -231,6 +238,11 @@ func newWorker(config *Config, chainConfig *params.ChainConfig, engine consensus
 		// Subscribe events for blockchain
 		worker.chainHeadSub = eth.BlockChain().SubscribeChainHeadEvent(worker.chainHeadCh)
 		worker.chainSideSub = eth.BlockChain().SubscribeChainSideEvent(worker.chainSideCh)
+		// Quorum: subscribe to the blocks the engine expects the next block to be built on
+		if speculator, ok := engine.(consensus.Speculator); ok {
+			worker.speculativeCh = make(chan consensus.SpeculativeParent, 1)
+			worker.speculativeSub = speculator.SubscribeSpeculative(worker.speculativeCh)
+		}
 		// Sanitize recommit interval if the user-specified one is too short.
 		recommit := worker.config.Recommit
 		if recommit < minRecommitInterval {
@@ -469,12 +481,19 @@ func (w *worker) mainLoop() {
 	defer w.txsSub.Unsubscribe()
 	defer w.chainHeadSub.Unsubscribe()
 	defer w.chainSideSub.Unsubscribe()
+	if w.speculativeSub != nil {
+		defer w.speculativeSub.Unsubscribe()
+	}
 
 	for {
 		select {
 		case req := <-w.newWorkCh:
 			w.commitNewWork(req.interrupt, req.noempty, req.timestamp)
 
+		// Quorum
+		case parent := <-w.speculativeCh:
+			w.commitSpeculativeWork(parent)
+
 		case ev := <-w.chainSideCh:
 			// Short circuit for duplicate side blocks
 			if _, exist := w.localUncles[ev.Block.Hash()]; exist {
@@ -549,6 +568,11 @@ func (w *worker) mainLoop() {
 				if w.chainConfig.Clique != nil && w.chainConfig.Clique.Period == 0 {
 					w.commitNewWork(nil, true, time.Now().Unix())
 				}
+				// Quorum: replace an empty block held back by the engine right away,
+				// instead of at the next recommit
+				if holder, ok := w.engine.(consensus.EmptyBlockHolder); ok && holder.HoldsEmptyBlock() {
+					w.commitNewWork(nil, true, time.Now().Unix())
+				}
 			}
 			atomic.AddInt32(&w.newTxs, int32(len(ev.Txs)))
 
@@ -760,6 +784,7 @@ func (w *worker) makeCurrent(parent *types.Block, header *types.Header) error {
 		header:    header,
 		// Quorum
 		privateStateRepo: privateStateRepo,
+		chain:            w.chain,
 	}
 	// when 08 is processed ancestors contain 07 (quick block)
 	for _, ancestor := range w.chain.GetBlocksFromHash(parent.Hash(), 7) {
@@ -853,7 +878,7 @@ func (w *worker) commitTransaction(tx *types.Transaction, coinbase common.Addres
 	privateStateDB.Prepare(tx.Hash(), common.Hash{}, workerEnv.tcount)
 	publicStateDB.Prepare(tx.Hash(), common.Hash{}, workerEnv.tcount)
 	privateStateSnaphots[privateStateRepo.DefaultStateMetadata().ID] = privateStateDB.Snapshot()
-	receipt, privateReceipt, err := core.ApplyTransaction(w.chainConfig, w.chain, &coinbase, workerEnv.gasPool, publicStateDB, privateStateDB, workerEnv.header, tx, &workerEnv.header.GasUsed, *w.chain.GetVMConfig(), privateStateRepo.IsMPS(), privateStateRepo, false)
+	receipt, privateReceipt, err := core.ApplyTransaction(w.chainConfig, workerEnv.chain, &coinbase, workerEnv.gasPool, publicStateDB, privateStateDB, workerEnv.header, tx, &workerEnv.header.GasUsed, *w.chain.GetVMConfig(), privateStateRepo.IsMPS(), privateStateRepo, false)
 	if err != nil {
 		publicStateDB.RevertToSnapshot(snap)
 		w.revertToPrivateStateSnapshots(privateStateSnaphots)
@@ -1002,6 +1027,34 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	tstart := time.Now()
 	parent := w.chain.CurrentBlock()
 
+	// Quorum: submit the work built while the parent was decided, if it was, topped up
+	// with the transactions which arrived since. The ones it includes already are
+	// skipped for their nonces. The work is kept while its parent may still be committed.
+	if speculative := w.speculative; speculative != nil {
+		switch {
+		case speculative.header.ParentHash == parent.Hash():
+			w.speculative = nil
+			log.Debug("Reusing speculative work", "number", speculative.header.Number, "parent", parent.Hash())
+			if w.current != nil && w.current.state != nil {
+				w.current.state.StopPrefetcher()
+			}
+			w.current = speculative
+			pending, err := w.eth.TxPool().Pending()
+			if err != nil {
+				log.Error("Failed to fetch pending transactions", "err", err)
+				return
+			}
+			if w.commitPendingTransactions(pending, interrupt) {
+				return
+			}
+			w.commit(nil, w.fullTaskHook, true, tstart)
+			return
+		case speculative.header.Number.Uint64() <= parent.NumberU64()+1:
+			w.speculative = nil
+			log.Debug("Discarding speculative work", "number", speculative.header.Number, "parent", speculative.header.ParentHash)
+		}
+	}
+
 	if parent.Time() >= uint64(timestamp) {
 		timestamp = int64(parent.Time() + 1)
 	}
@@ -1094,6 +1147,15 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 		w.updateSnapshot()
 		return
 	}
+	if w.commitPendingTransactions(pending, interrupt) {
+		return
+	}
+	w.commit(uncles, w.fullTaskHook, true, tstart)
+}
+
+// commitPendingTransactions fills the current block with the pending transactions,
+// the local ones first. It returns true if the work was interrupted by a new head.
+func (w *worker) commitPendingTransactions(pending map[common.Address]types.Transactions, interrupt *int32) bool {
 	// Split the pending transactions into locals and remotes
 	localTxs, remoteTxs := make(map[common.Address]types.Transactions), pending
 	for _, account := range w.eth.TxPool().Locals() {
@@ -1105,16 +1167,115 @@ func (w *worker) commitNewWork(interrupt *int32, noempty bool, timestamp int64)
 	if len(localTxs) > 0 {
 		txs := types.NewTransactionsByPriceAndNonce(w.current.signer, localTxs)
 		if w.commitTransactions(txs, w.coinbase, interrupt) {
-			return
+			return true
 		}
 	}
 	if len(remoteTxs) > 0 {
 		txs := types.NewTransactionsByPriceAndNonce(w.current.signer, remoteTxs)
 		if w.commitTransactions(txs, w.coinbase, interrupt) {
-			return
+			return true
 		}
 	}
-	w.commit(uncles, w.fullTaskHook, true, tstart)
+	return false
+}
+
+// Quorum
+//
+// commitSpeculativeWork builds the next block on a parent the consensus engine agreed
+// on, while the parent is being committed. The work is kept aside and submitted by
+// commitNewWork once the parent is the head, or thrown away if another block is.
+func (w *worker) commitSpeculativeWork(parent consensus.SpeculativeParent) {
+	if !w.isRunning() || parent.Block == nil || parent.State == nil {
+		return
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.coinbase == (common.Address{}) {
+		return
+	}
+	tstart := time.Now()
+	privateStateRepo, err := w.chain.ExecutedPrivateState(parent.Block.Hash())
+	if err != nil {
+		log.Debug("Skipping speculative work", "parent", parent.Block.Hash(), "err", err)
+		return
+	}
+	chain := &speculativeChain{BlockChain: w.chain, parent: parent.Block.Header(), parentHash: parent.Block.Hash()}
+
+	timestamp := uint64(time.Now().Unix())
+	if parent.Block.Time() >= timestamp {
+		timestamp = parent.Block.Time() + 1
+	}
+	minGasLimit := w.chainConfig.GetMinerMinGasLimit(parent.Block.Number(), params.DefaultMinGasLimit)
+	header := &types.Header{
+		ParentHash: parent.Block.Hash(),
+		Number:     new(big.Int).Add(parent.Block.Number(), common.Big1),
+		GasLimit:   core.CalcGasLimit(parent.Block, minGasLimit, w.config.GasFloor, w.config.GasCeil),
+		Extra:      w.extra,
+		Time:       timestamp,
+		Coinbase:   w.coinbase,
+	}
+	if err := w.engine.Prepare(chain, header); err != nil {
+		log.Debug("Failed to prepare speculative header", "err", err)
+		return
+	}
+	env := &environment{
+		signer:           types.MakeSigner(w.chainConfig, header.Number),
+		state:            parent.State,
+		ancestors:        mapset.NewSet(),
+		family:           mapset.NewSet(),
+		uncles:           mapset.NewSet(),
+		header:           header,
+		privateStateRepo: privateStateRepo,
+		chain:            chain,
+	}
+
+	// the pending transactions are those of the head, the ones included in the parent
+	// are skipped for their nonces
+	pending, err := w.eth.TxPool().Pending()
+	if err != nil {
+		log.Error("Failed to fetch pending transactions", "err", err)
+		return
+	}
+	current := w.current
+	w.current = env
+	w.commitPendingTransactions(pending, nil)
+	w.current = current
+
+	w.speculative = env
+	log.Debug("Built speculative work", "number", header.Number, "parent", header.ParentHash, "txs", env.tcount,
+		"elapsed", common.PrettyDuration(time.Since(tstart)))
+}
+
+// speculativeChain is the local chain extended by a parent block which is not
+// committed yet
+type speculativeChain struct {
+	*core.BlockChain
+	parent     *types.Header
+	parentHash common.Hash
+}
+
+func (c *speculativeChain) CurrentHeader() *types.Header { return c.parent }
+
+func (c *speculativeChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if hash == c.parentHash {
+		return c.parent
+	}
+	return c.BlockChain.GetHeader(hash, number)
+}
+
+func (c *speculativeChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if hash == c.parentHash {
+		return c.parent
+	}
+	return c.BlockChain.GetHeaderByHash(hash)
+}
+
+func (c *speculativeChain) GetHeaderByNumber(number uint64) *types.Header {
+	if number == c.parent.Number.Uint64() {
+		return c.parent
+	}
+	return c.BlockChain.GetHeaderByNumber(number)
 }
 
 // commit runs any post-transaction state modifications, assembles the final block
@@ -1221,7 +1382,7 @@ func (w *worker) handleMPS(tx *types.Transaction, coinbase common.Address, apply
 			privateStateSnaphots[psi] = db.Snapshot()
 			return db, nil
 		}
-		mpsReceipt, err = core.ApplyTransactionOnMPS(w.chainConfig, w.chain, &coinbase, workerEnv.gasPool, publicStateDBFactory, privateStateDBFactory, workerEnv.header, tx, &workerEnv.header.GasUsed, *w.chain.GetVMConfig(), privateStateRepo, applyOnPartyOnly, false)
+		mpsReceipt, err = core.ApplyTransactionOnMPS(w.chainConfig, workerEnv.chain, &coinbase, workerEnv.gasPool, publicStateDBFactory, privateStateDBFactory, workerEnv.header, tx, &workerEnv.header.GasUsed, *w.chain.GetVMConfig(), privateStateRepo, applyOnPartyOnly, false)
 	}
 	return
 }
@@ -545,6 +545,130 @@ var PSI2PSM = mps.PrivateStateMetadata{
 	Addresses:   nil,
 }
 
+// Quorum
+func TestSpeculativeWorkReuse(t *testing.T) {
+	testSpeculativeWork(t, true)
+}
+
+// Quorum
+func TestSpeculativeWorkDiscard(t *testing.T) {
+	testSpeculativeWork(t, false)
+}
+
+// testSpeculativeWork builds work on a parent executed but not written yet, as the
+// HotStuff engine announces it, then commits either that parent or a competing block.
+// Work on the committed parent is submitted as built, work on another block is thrown
+// away for work built on the new head.
+func testSpeculativeWork(t *testing.T, reuse bool) {
+	engine := ethash.NewFaker()
+	defer engine.Close()
+
+	w, b := newTestWorker(t, ethashChainConfig, engine, rawdb.NewMemoryDatabase(), 0)
+	defer w.close()
+
+	var (
+		genesis = b.chain.CurrentBlock()
+		signer  = types.LatestSigner(ethashChainConfig)
+		taskCh  = make(chan *task, 10)
+	)
+	w.newTaskHook = func(task *task) {
+		if task.block.NumberU64() == 2 {
+			taskCh <- task
+		}
+	}
+	w.skipSealHook = func(task *task) bool { return true }
+	w.fullTaskHook = func() {}
+
+	// the speculative parent includes the first pending transaction, the competing
+	// block none
+	parent, _ := core.GenerateChain(ethashChainConfig, genesis, engine, b.db, 1, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(testBankAddress)
+		gen.AddTx(pendingTxs[0])
+	})
+	other, _ := core.GenerateChain(ethashChainConfig, genesis, engine, b.db, 1, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(testUserAddress)
+	})
+	b.txPool.AddLocals(newTxs)
+
+	// run without the work loop starting work on its own
+	atomic.StoreInt32(&w.running, 1)
+
+	statedb, receipts, logs, err := b.chain.ExecuteBlock(parent[0])
+	if err != nil {
+		t.Fatalf("failed to execute parent: %v", err)
+	}
+	current := w.current
+	w.commitSpeculativeWork(consensus.SpeculativeParent{Block: parent[0], State: statedb.Copy()})
+
+	speculative := w.speculative
+	if speculative == nil {
+		t.Fatalf("no speculative work built")
+	}
+	if w.current != current {
+		t.Errorf("current work replaced by speculative work")
+	}
+	if speculative.header.ParentHash != parent[0].Hash() || speculative.header.Number.Uint64() != 2 {
+		t.Fatalf("speculative work on the wrong parent, number %d parent %v", speculative.header.Number, speculative.header.ParentHash)
+	}
+	if speculative.tcount != 1 || speculative.txs[0].Hash() != newTxs[0].Hash() {
+		t.Fatalf("speculative work includes %d transactions, want the one after the parent", speculative.tcount)
+	}
+	// a transaction arriving meanwhile is added when the work is reused
+	late := types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+		Nonce: 2,
+		To:    &testUserAddress,
+		Value: big.NewInt(1000),
+		Gas:   params.TxGas,
+	})
+	b.txPool.AddLocal(late)
+
+	head := parent[0]
+	if reuse {
+		if err := b.chain.WriteExecutedBlock(parent[0], receipts, logs, statedb); err != nil {
+			t.Fatalf("failed to write parent: %v", err)
+		}
+	} else {
+		head = other[0]
+		if _, err := b.chain.InsertChain(other); err != nil {
+			t.Fatalf("failed to insert competing block: %v", err)
+		}
+	}
+
+	// fresh work is submitted empty first, speculative work never is
+	var task *task
+	for task == nil || (!reuse && len(task.block.Transactions()) == 0) {
+		select {
+		case task = <-taskCh:
+		case <-time.After(3 * time.Second):
+			t.Fatalf("no work submitted on the new head")
+		}
+	}
+	if task.block.ParentHash() != head.Hash() {
+		t.Fatalf("work on the wrong parent, want %v, got %v", head.Hash(), task.block.ParentHash())
+	}
+	txs := task.block.Transactions()
+	if reuse {
+		// the speculative work is submitted with the late transaction appended
+		if task.block.Time() != speculative.header.Time || len(txs) != 2 || txs[0].Hash() != newTxs[0].Hash() {
+			t.Fatalf("speculative work not reused, %d transactions at time %d, want 2 at %d", len(txs), task.block.Time(), speculative.header.Time)
+		}
+		if txs[1].Hash() != late.Hash() {
+			t.Errorf("late transaction not added to the speculative work, got %v", txs[1].Hash())
+		}
+	} else {
+		// fresh work on the competing block includes every pending transaction
+		if len(txs) != 3 {
+			t.Errorf("work on the competing block includes %d transactions, want 3", len(txs))
+		}
+	}
+	w.mu.RLock()
+	left := w.speculative
+	w.mu.RUnlock()
+	if left != nil {
+		t.Errorf("speculative work kept after its height was built on")
+	}
+}
+
 func TestPrivatePSMRStateCreated(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -17,6 +17,7 @@
 package node
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
@@ -41,6 +42,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/plugin"
 	"github.com/ethereum/go-ethereum/rpc"
+	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"go.dedis.ch/kyber/v3/share"
 )
@@ -53,6 +55,7 @@ const (
 	datadirNodeDatabase    = "nodes"              // Path within the datadir to store the node infos
 	datadirBLSPrivateKey   = "bls-private-key.json"
 	datadirBLSPublicKey    = "bls-public-key.json"
+	datadirBLSMultiSigKey  = "bls-multisig-key.json"
 )
 
 // Config represents a small collection of configuration values to fine tune the
@@ -335,13 +338,14 @@ func (c *Config) name() string {
 
 // These resources are resolved differently for "geth" instances.
 var isOldGethResource = map[string]bool{
-	"chaindata":            true,
-	"nodes":                true,
-	"nodekey":              true,
-	"bls-private-key.json": true,
-	"bls-public-key.json":  true,
-	"static-nodes.json":    false, // no warning for these because they have their
-	"trusted-nodes.json":   false, // own separate warning.
+	"chaindata":             true,
+	"nodes":                 true,
+	"nodekey":               true,
+	"bls-private-key.json":  true,
+	"bls-public-key.json":   true,
+	"bls-multisig-key.json": true,
+	"static-nodes.json":     false, // no warning for these because they have their
+	"trusted-nodes.json":    false, // own separate warning.
 }
 
 // ResolvePath resolves path in the instance directory.
@@ -612,6 +616,11 @@ type PriShare struct {
 	Pri   []byte `json:"Pri"`
 }
 
+// MultiSigKey is the validator BLS private key of the multi-signature scheme
+type MultiSigKey struct {
+	Pri []byte `json:"Pri"`
+}
+
 type PubShare struct {
 	Index int    `json:"Index"`
 	Pub   []byte `json:"Pub"`
@@ -649,36 +658,71 @@ func decodePubShare(suite *bn256.Suite, keyfile string, n, t int) *share.PubPoly
 	return pubKey
 }
 
-func decodePriShare(suite *bn256.Suite, keyfile string) *share.PriShare {
-	log.Info("decodePriShare", "keyfile", keyfile)
-	// Read private key from file.
+// Decode private shares from the private key file. Validators with a weight above 1
+// hold one share per unit of voting power, stored as a json array, otherwise the
+// file holds a single share object.
+func decodePriShares(suite *bn256.Suite, keyfile string) []*share.PriShare {
+	log.Info("decodePriShares", "keyfile", keyfile)
+	// Read private keys from file.
 	plan, _ := ioutil.ReadFile(keyfile)
-	var data PriShare
-	err := json.Unmarshal(plan, &data)
-	if err != nil {
-		log.Error("Couldn't Unmarshal private key json", "err", err)
+	var data []PriShare
+	if trimmed := bytes.TrimSpace(plan); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(plan, &data); err != nil {
+			log.Error("Couldn't Unmarshal private key json", "err", err)
+		}
+	} else {
+		data = make([]PriShare, 1)
+		if err := json.Unmarshal(plan, &data[0]); err != nil {
+			log.Error("Couldn't Unmarshal private key json", "err", err)
+		}
 	}
 
-	scalar := suite.G2().Scalar()
-	err = scalar.UnmarshalBinary(data.Pri)
-	if err != nil {
-		log.Error("Couldn't Unmarshal private key binary", "err", err)
+	partialPriKeys := make([]*share.PriShare, len(data))
+	for i, d := range data {
+		scalar := suite.G2().Scalar()
+		if err := scalar.UnmarshalBinary(d.Pri); err != nil {
+			log.Error("Couldn't Unmarshal private key binary", "idx", i, "err", err)
+		}
+		partialPriKeys[i] = &share.PriShare{I: d.Index, V: scalar}
 	}
 
-	partialPriKey := &share.PriShare{I: data.Index, V: scalar}
+	// Construct prishare structs and return.
+	return partialPriKeys
+}
 
-	// Construct a prishare struct and return.
-	return partialPriKey
+// BLSMultiSigKey returns the validator BLS key used by the multi-signature scheme,
+// read from its own key file rather than from the threshold key shares
+func (c *Config) BLSMultiSigKey() (*bn256.Suite, kyber.Scalar) {
+	c.Logger.Info("Reading BLS multi-signature key")
+	suite := bn256.NewSuite()
+
+	keyfile := c.ResolvePath(datadirBLSMultiSigKey)
+	plan, err := ioutil.ReadFile(keyfile)
+	if err != nil {
+		c.Logger.Error("Missing BLS multi-signature key", "file", keyfile, "err", err)
+		return suite, nil
+	}
+	var data MultiSigKey
+	if err := json.Unmarshal(plan, &data); err != nil {
+		c.Logger.Error("Couldn't Unmarshal multi-signature key json", "err", err)
+		return suite, nil
+	}
+	scalar := suite.G2().Scalar()
+	if err := scalar.UnmarshalBinary(data.Pri); err != nil {
+		c.Logger.Error("Couldn't Unmarshal multi-signature key binary", "err", err)
+		return suite, nil
+	}
+	return suite, scalar
 }
 
-func (c *Config) BLSKeys(n, t int) (*bn256.Suite, *share.PubPoly, *share.PriShare) {
+func (c *Config) BLSKeys(n, t int) (*bn256.Suite, *share.PubPoly, []*share.PriShare) {
 	c.Logger.Info("Reading BLS Keys", "n", n, "t", t)
 	suite := bn256.NewSuite()
 
 	pubkeyFile := c.ResolvePath(datadirBLSPublicKey)
 	pubkey := decodePubShare(suite, pubkeyFile, n, t)
 	privkeyFile := c.ResolvePath(datadirBLSPrivateKey)
-	privkey := decodePriShare(suite, privkeyFile)
+	privkeys := decodePriShares(suite, privkeyFile)
 
-	return suite, pubkey, privkey
+	return suite, pubkey, privkeys
 }
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/log"
 	"golang.org/x/crypto/sha3"
@@ -387,11 +388,50 @@ func (c *EthashConfig) String() string {
 }
 
 type HotStuffConfig struct {
-	RequestTimeoutMilliseconds uint64           `json:"requesttimeoutmilliseconds"` // The timeout for each HotStuff round in milliseconds.
-	BlockPeriodSeconds         uint64           `json:"blockperiodseconds"`         // Default minimum difference between two consecutive block's timestamps in second for basic hotstuff and mill-seconds for event-driven
-	LeaderPolicy               string           `json:"policy"`                     // The policy for speaker selection
-	FaultyMode                 string           `json:"faultymode"`                 // The faulty node indicates the faulty node's behavior
-	Validators                 []common.Address `json:"validators"`                 // Validators list
+	RequestTimeoutMilliseconds uint64           `json:"requesttimeoutmilliseconds"`        // The timeout for each HotStuff round in milliseconds.
+	BlockPeriodSeconds         uint64           `json:"blockperiodseconds"`                // Default minimum difference between two consecutive block's timestamps in second for basic hotstuff and mill-seconds for event-driven
+	BlockPeriodMilliseconds    uint64           `json:"blockperiodmilliseconds,omitempty"` // Minimum difference between two consecutive block's timestamps in milliseconds, overrides BlockPeriodSeconds if set
+	EmptyBlockPeriodSeconds    uint64           `json:"emptyblockperiodseconds,omitempty"` // Minimum difference between the timestamps of a block and an empty child in seconds, ignored unless longer than the block period
+	LeaderPolicy               string           `json:"policy"`                            // The policy for speaker selection
+	FaultyMode                 string           `json:"faultymode"`                        // The faulty node indicates the faulty node's behavior
+	ReputationWindow           uint64           `json:"reputationwindow,omitempty"`        // Number of recent blocks scored by the Reputation leader policy
+	Validators                 []common.Address `json:"validators"`                        // Validators list
+	Weights                    []uint64         `json:"weights,omitempty"`                 // Voting power of each validator, in the order of Validators. Defaults to 1 each
+	SignatureScheme            string           `json:"signaturescheme,omitempty"`         // The BLS scheme, "Threshold" (default) or "MultiSig"
+	BLSKeys                    []HotStuffBLSKey `json:"blskeys,omitempty"`                 // Validator BLS public keys for the MultiSig scheme
+	Topology                   string           `json:"topology,omitempty"`                // The message overlay, "Star" (default) or "Tree"
+	TreeFanout                 uint64           `json:"treefanout,omitempty"`              // Number of children per node in the Tree topology, sqrt(n) if 0
+	CompactProposal            bool             `json:"compactproposal,omitempty"`         // Send transaction hashes instead of full blocks in Prepare messages
+	ValidatorContract          common.Address   `json:"validatorcontract,omitempty"`       // Contract managing the validator set after genesis, read at the parent state of every block
+	CensorshipViews            uint64           `json:"censorshipviews,omitempty"`         // Proposals with spare gas a pending transaction may be left out of before the leader is suspected, 0 disables detection
+	CensorshipAction           string           `json:"censorshipaction,omitempty"`        // What replicas do about a suspected leader, "Flag" (default) or "ViewChange"
+
+	BlockReward     *math.HexOrDecimal256 `json:"blockreward,omitempty"`     // Reward minted with every block, none by default
+	BeneficiaryMode string                `json:"beneficiarymode,omitempty"` // Who receives the block reward, "proposer" (default), "fixed" or "validators"
+	Beneficiaries   []common.Address      `json:"beneficiaries,omitempty"`   // Accounts sharing the block reward in the fixed beneficiary mode
+}
+
+const (
+	HotStuffBeneficiaryProposer   = "proposer"   // The block reward goes to the proposer of the block
+	HotStuffBeneficiaryFixed      = "fixed"      // The block reward is shared among a fixed list of beneficiaries
+	HotStuffBeneficiaryValidators = "validators" // The block reward is shared among the signers of the parent commit QC, or all validators under the threshold scheme
+)
+
+// IsHotStuffBeneficiaryMode reports whether mode is a beneficiary mode of the HotStuff
+// block reward, the empty mode standing for the proposer
+func IsHotStuffBeneficiaryMode(mode string) bool {
+	switch mode {
+	case "", HotStuffBeneficiaryProposer, HotStuffBeneficiaryFixed, HotStuffBeneficiaryValidators:
+		return true
+	}
+	return false
+}
+
+// HotStuffBLSKey is the BLS public key of a validator along with its proof of possession
+type HotStuffBLSKey struct {
+	Address   common.Address `json:"address"`
+	PublicKey hexutil.Bytes  `json:"publickey"`
+	Proof     hexutil.Bytes  `json:"proof"`
 }
 
 // String implements the stringer interface, returning the consensus engine details.
@@ -485,6 +525,7 @@ type Transition struct {
 	BlockReward                  *math.HexOrDecimal256 `json:"blockReward,omitempty"`                  // validation rewards
 	BeneficiaryMode              *string               `json:"beneficiaryMode,omitempty"`              // Mode for setting the beneficiary, either: list, besu, validators (beneficiary list is the list of validators)
 	MiningBeneficiary            *common.Address       `json:"miningBeneficiary,omitempty"`            // Wallet address that benefits at every new block (besu mode)
+	Beneficiaries                []common.Address      `json:"beneficiaries,omitempty"`                // Accounts sharing the block reward in the HotStuff fixed beneficiary mode
 }
 
 // String implements the fmt.Stringer interface.
@@ -704,6 +745,39 @@ func (c *ChainConfig) GetBlockReward(num *big.Int) big.Int {
 	return big.Int(blockReward)
 }
 
+// GetHotStuffReward returns the block reward of a HotStuff chain at the given height,
+// with the beneficiary mode and the beneficiaries of the fixed mode in force
+func (c *ChainConfig) GetHotStuffReward(num *big.Int) (*big.Int, string, []common.Address) {
+	var (
+		blockReward   = new(big.Int)
+		mode          = HotStuffBeneficiaryProposer
+		beneficiaries []common.Address
+	)
+	if c.HotStuff != nil {
+		if c.HotStuff.BlockReward != nil {
+			blockReward = (*big.Int)(c.HotStuff.BlockReward)
+		}
+		if c.HotStuff.BeneficiaryMode != "" {
+			mode = c.HotStuff.BeneficiaryMode
+		}
+		beneficiaries = c.HotStuff.Beneficiaries
+	}
+
+	c.GetTransitionValue(num, func(transition Transition) {
+		if transition.BlockReward != nil {
+			blockReward = (*big.Int)(transition.BlockReward)
+		}
+		if transition.BeneficiaryMode != nil {
+			mode = *transition.BeneficiaryMode
+		}
+		if len(transition.Beneficiaries) > 0 {
+			beneficiaries = transition.Beneficiaries
+		}
+	})
+
+	return new(big.Int).Set(blockReward), mode, beneficiaries
+}
+
 // Quorum
 // gets value at or after a transition
 func (c *ChainConfig) GetTransitionValue(num *big.Int, callback func(transition Transition)) {
@@ -796,9 +870,12 @@ func (c *ChainConfig) CheckTransitionsData() error {
 		if transition.TransactionSizeLimit != 0 && transition.TransactionSizeLimit < 32 || transition.TransactionSizeLimit > 128 {
 			return ErrTransactionSizeLimit
 		}
-		if transition.BeneficiaryMode != nil && *transition.BeneficiaryMode != "fixed" && *transition.BeneficiaryMode != "validators" && *transition.BeneficiaryMode != "" && *transition.BeneficiaryMode != "list" {
+		if transition.BeneficiaryMode != nil && *transition.BeneficiaryMode != "fixed" && *transition.BeneficiaryMode != "validators" && *transition.BeneficiaryMode != "" && *transition.BeneficiaryMode != "list" && *transition.BeneficiaryMode != HotStuffBeneficiaryProposer {
 			return ErrBeneficiaryMode
 		}
+		if c.HotStuff != nil && transition.BeneficiaryMode != nil && !IsHotStuffBeneficiaryMode(*transition.BeneficiaryMode) {
+			return ErrHotStuffBeneficiaryMode
+		}
 		prevBlock = transition.Block
 	}
 	return nil
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 )
 
 // Quorum - test code size and transaction size limit in chain config
@@ -331,11 +332,12 @@ func TestCheckTransitionsData(t *testing.T) {
 	}
 	var ibftTransitionsConfig, qbftTransitionsConfig, invalidTransition, invalidBlockOrder []Transition
 	var emptyBlockPeriodSeconds uint64 = 10
+	hotstuffValidators, istanbulList := HotStuffBeneficiaryValidators, "list"
 
-	tranI0 := Transition{big.NewInt(0), IBFT, 30000, 5, nil, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil}
-	tranQ5 := Transition{big.NewInt(5), QBFT, 30000, 5, &emptyBlockPeriodSeconds, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil}
-	tranI10 := Transition{big.NewInt(10), IBFT, 30000, 5, nil, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil}
-	tranQ8 := Transition{big.NewInt(8), QBFT, 30000, 5, &emptyBlockPeriodSeconds, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil}
+	tranI0 := Transition{big.NewInt(0), IBFT, 30000, 5, nil, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil, nil}
+	tranQ5 := Transition{big.NewInt(5), QBFT, 30000, 5, &emptyBlockPeriodSeconds, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil, nil}
+	tranI10 := Transition{big.NewInt(10), IBFT, 30000, 5, nil, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil, nil}
+	tranQ8 := Transition{big.NewInt(8), QBFT, 30000, 5, &emptyBlockPeriodSeconds, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil, nil}
 
 	ibftTransitionsConfig = append(ibftTransitionsConfig, tranI0, tranI10)
 	qbftTransitionsConfig = append(qbftTransitionsConfig, tranQ5, tranQ8)
@@ -395,7 +397,7 @@ func TestCheckTransitionsData(t *testing.T) {
 			wantErr: ErrBlockOrder,
 		},
 		{
-			stored:  &ChainConfig{Transitions: []Transition{{nil, IBFT, 30000, 5, &emptyBlockPeriodSeconds, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil}}},
+			stored:  &ChainConfig{Transitions: []Transition{{nil, IBFT, 30000, 5, &emptyBlockPeriodSeconds, 10, 50, common.Address{}, nil, "", nil, nil, nil, nil, 0, nil, 0, nil, nil, nil, nil}}},
 			wantErr: ErrBlockNumberMissing,
 		},
 		{
@@ -426,6 +428,14 @@ func TestCheckTransitionsData(t *testing.T) {
 			stored:  &ChainConfig{Transitions: []Transition{{Block: big.NewInt(0)}}},
 			wantErr: nil,
 		},
+		{
+			stored:  &ChainConfig{HotStuff: &HotStuffConfig{}, Transitions: []Transition{{Block: big.NewInt(0), BeneficiaryMode: &hotstuffValidators}}},
+			wantErr: nil,
+		},
+		{
+			stored:  &ChainConfig{HotStuff: &HotStuffConfig{}, Transitions: []Transition{{Block: big.NewInt(0), BeneficiaryMode: &istanbulList}}},
+			wantErr: ErrHotStuffBeneficiaryMode,
+		},
 	}
 
 	for _, test := range tests {
@@ -491,6 +501,48 @@ func TestGetMaxCodeSize(t *testing.T) {
 	}
 }
 
+func TestGetHotStuffReward(t *testing.T) {
+	type test struct {
+		config        *ChainConfig
+		blockNumber   int64
+		reward        int64
+		mode          string
+		beneficiaries []common.Address
+	}
+	var (
+		fixed      = "fixed"
+		validators = "validators"
+		list1      = []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02")}
+		list2      = []common.Address{common.HexToAddress("0x03")}
+	)
+	config1, config2 := *TestChainConfig, *TestChainConfig
+	config1.HotStuff = &HotStuffConfig{}
+	config2.HotStuff = &HotStuffConfig{
+		BlockReward:     math.NewHexOrDecimal256(10),
+		BeneficiaryMode: HotStuffBeneficiaryFixed,
+		Beneficiaries:   list1,
+	}
+	config2.Transitions = []Transition{
+		{Block: big.NewInt(2), BlockReward: math.NewHexOrDecimal256(20), Beneficiaries: list2},
+		{Block: big.NewInt(4), BeneficiaryMode: &validators},
+		{Block: big.NewInt(6), BeneficiaryMode: &fixed},
+	}
+
+	tests := []test{
+		{&config1, 0, 0, HotStuffBeneficiaryProposer, nil},
+		{&config2, 0, 10, HotStuffBeneficiaryFixed, list1},
+		{&config2, 2, 20, HotStuffBeneficiaryFixed, list2},
+		{&config2, 4, 20, HotStuffBeneficiaryValidators, list2},
+		{&config2, 6, 20, HotStuffBeneficiaryFixed, list2},
+	}
+	for _, test := range tests {
+		reward, mode, beneficiaries := test.config.GetHotStuffReward(big.NewInt(test.blockNumber))
+		if reward.Int64() != test.reward || mode != test.mode || !reflect.DeepEqual(beneficiaries, test.beneficiaries) {
+			t.Errorf("reward mismatch on %v:\nexpected: %v %v %v\nreceived: %v %v %v\n", test.blockNumber, test.reward, test.mode, test.beneficiaries, reward, mode, beneficiaries)
+		}
+	}
+}
+
 func TestIsQIP714(t *testing.T) {
 	type test struct {
 		config      *ChainConfig
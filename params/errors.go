@@ -17,6 +17,7 @@ var (
 	ErrMissingValidatorSelectionMode   = errors.New("validator selection mode is missing, should specify `contract` when using validatorcontractaddress")
 	ErrTransactionSizeLimit            = errors.New("genesis transaction size limit must be between 32 and 128")
 	ErrBeneficiaryMode                 = errors.New("beneficiary mode is not valid")
+	ErrHotStuffBeneficiaryMode         = errors.New("hotstuff beneficiary mode must be proposer, fixed or validators")
 )
 
 func ErrTransitionIncompatible(field string) error {
@@ -15,6 +15,10 @@
 // generate mocks for unit testing
 //go:generate mockgen -package proto_common -destination proto_common/mock_init.go -source proto_common/init.pb.go
 
+// generate stubs and mocks for plugin interfaces defined in this repository
+//go:generate protoc -I ../hotstuff/proto --go_out=plugins=grpc,paths=source_relative:../hotstuff/proto hotstuff.proto
+//go:generate mockgen -package proto -destination ../hotstuff/proto/mock_hotstuff.go -source ../hotstuff/proto/hotstuff.pb.go
+
 // fix fmt
 //go:generate goimports -w ./
 
@@ -0,0 +1,46 @@
+package hotstuff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/plugin/hotstuff/proto"
+)
+
+type PluginGateway struct {
+	client proto.PluginHotStuffSignerClient
+}
+
+var _ PluginSigner = &PluginGateway{}
+
+func (p *PluginGateway) Address(ctx context.Context) (common.Address, error) {
+	resp, err := p.client.Address(ctx, &proto.Address_Request{})
+	if err != nil {
+		return common.Address{}, fmt.Errorf("get address: %w", err)
+	}
+	if len(resp.Address) != common.AddressLength {
+		return common.Address{}, fmt.Errorf("invalid address length %d", len(resp.Address))
+	}
+	return common.BytesToAddress(resp.Address), nil
+}
+
+func (p *PluginGateway) Sign(ctx context.Context, hash common.Hash) ([]byte, error) {
+	resp, err := p.client.Sign(ctx, &proto.Sign_Request{
+		Hash: hash.Bytes(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	return resp.Signature, nil
+}
+
+func (p *PluginGateway) BLSSign(ctx context.Context, data []byte) ([]byte, error) {
+	resp, err := p.client.BLSSign(ctx, &proto.BLSSign_Request{
+		Data: data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("bls sign: %w", err)
+	}
+	return resp.Signature, nil
+}
@@ -0,0 +1,90 @@
+package hotstuff
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/plugin/hotstuff/proto"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginGateway_Address(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	addr := common.HexToAddress("0x1932c48b2bf8102ba33b4a6b545c32236e342f34")
+	mockClient := proto.NewMockPluginHotStuffSignerClient(ctrl)
+	mockClient.
+		EXPECT().
+		Address(gomock.Any(), gomock.Any()).
+		Return(&proto.Address_Response{
+			Address: addr.Bytes(),
+		}, nil)
+	testObject := &PluginGateway{client: mockClient}
+
+	resp, err := testObject.Address(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, addr, resp)
+}
+
+func TestPluginGateway_Address_whenInvalidLength(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockClient := proto.NewMockPluginHotStuffSignerClient(ctrl)
+	mockClient.
+		EXPECT().
+		Address(gomock.Any(), gomock.Any()).
+		Return(&proto.Address_Response{
+			Address: []byte{0x01},
+		}, nil)
+	testObject := &PluginGateway{client: mockClient}
+
+	_, err := testObject.Address(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestPluginGateway_Sign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	hash := common.HexToHash("0x01")
+	req := &proto.Sign_Request{
+		Hash: hash.Bytes(),
+	}
+	mockClient := proto.NewMockPluginHotStuffSignerClient(ctrl)
+	mockClient.
+		EXPECT().
+		Sign(gomock.Any(), gomock.Eq(req)).
+		Return(&proto.Sign_Response{
+			Signature: []byte("arbitrary signature"),
+		}, nil)
+	testObject := &PluginGateway{client: mockClient}
+
+	resp, err := testObject.Sign(context.Background(), hash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("arbitrary signature"), resp)
+}
+
+func TestPluginGateway_BLSSign(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	req := &proto.BLSSign_Request{
+		Data: []byte("arbitrary data"),
+	}
+	mockClient := proto.NewMockPluginHotStuffSignerClient(ctrl)
+	mockClient.
+		EXPECT().
+		BLSSign(gomock.Any(), gomock.Eq(req)).
+		Return(&proto.BLSSign_Response{
+			Signature: []byte("arbitrary signature"),
+		}, nil)
+	testObject := &PluginGateway{client: mockClient}
+
+	resp, err := testObject.BLSSign(context.Background(), []byte("arbitrary data"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("arbitrary signature"), resp)
+}
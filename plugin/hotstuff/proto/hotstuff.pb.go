@@ -0,0 +1,790 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        v3.9.0
+// source: hotstuff.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A wrapper message to logically group other messages
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{0}
+}
+
+// A wrapper message to logically group other messages
+type Sign struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Sign) Reset() {
+	*x = Sign{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sign) ProtoMessage() {}
+
+func (x *Sign) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sign.ProtoReflect.Descriptor instead.
+func (*Sign) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{1}
+}
+
+// A wrapper message to logically group other messages
+type BLSSign struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *BLSSign) Reset() {
+	*x = BLSSign{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BLSSign) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BLSSign) ProtoMessage() {}
+
+func (x *BLSSign) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BLSSign.ProtoReflect.Descriptor instead.
+func (*BLSSign) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{2}
+}
+
+type Address_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Address_Request) Reset() {
+	*x = Address_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address_Request) ProtoMessage() {}
+
+func (x *Address_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address_Request.ProtoReflect.Descriptor instead.
+func (*Address_Request) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type Address_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 20-byte validator address
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *Address_Response) Reset() {
+	*x = Address_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address_Response) ProtoMessage() {}
+
+func (x *Address_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address_Response.ProtoReflect.Descriptor instead.
+func (*Address_Response) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *Address_Response) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type Sign_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 32-byte hash to sign
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (x *Sign_Request) Reset() {
+	*x = Sign_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sign_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sign_Request) ProtoMessage() {}
+
+func (x *Sign_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sign_Request.ProtoReflect.Descriptor instead.
+func (*Sign_Request) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (x *Sign_Request) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+type Sign_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// 65-byte ECDSA signature in the [R || S || V] format, where V is 0 or 1
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Sign_Response) Reset() {
+	*x = Sign_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Sign_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sign_Response) ProtoMessage() {}
+
+func (x *Sign_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sign_Response.ProtoReflect.Descriptor instead.
+func (*Sign_Response) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{1, 1}
+}
+
+func (x *Sign_Response) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type BLSSign_Request struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Data to sign
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *BLSSign_Request) Reset() {
+	*x = BLSSign_Request{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BLSSign_Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BLSSign_Request) ProtoMessage() {}
+
+func (x *BLSSign_Request) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BLSSign_Request.ProtoReflect.Descriptor instead.
+func (*BLSSign_Request) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *BLSSign_Request) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type BLSSign_Response struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// With the Threshold scheme, the concatenated partial signatures of the key
+	// shares of the validator, each prefixed with its 2-byte share index.
+	// With the MultiSig scheme, the BLS signature of the validator key.
+	Signature []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *BLSSign_Response) Reset() {
+	*x = BLSSign_Response{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_hotstuff_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BLSSign_Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BLSSign_Response) ProtoMessage() {}
+
+func (x *BLSSign_Response) ProtoReflect() protoreflect.Message {
+	mi := &file_hotstuff_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BLSSign_Response.ProtoReflect.Descriptor instead.
+func (*BLSSign_Response) Descriptor() ([]byte, []int) {
+	return file_hotstuff_proto_rawDescGZIP(), []int{2, 1}
+}
+
+func (x *BLSSign_Response) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_hotstuff_proto protoreflect.FileDescriptor
+
+var file_hotstuff_proto_rawDesc = []byte{
+	0x0a, 0x0e, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x3a, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x1a, 0x09, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x0a,
+	0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x22, 0x4f, 0x0a, 0x04, 0x53, 0x69, 0x67, 0x6e, 0x1a, 0x1d, 0x0a, 0x07, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x1a, 0x28, 0x0a, 0x08, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x22, 0x52, 0x0a, 0x07, 0x42, 0x4c, 0x53, 0x53, 0x69, 0x67, 0x6e, 0x1a,
+	0x1d, 0x0a, 0x07, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x1a, 0x28,
+	0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x32, 0xc1, 0x01, 0x0a, 0x14, 0x50, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x48, 0x6f, 0x74, 0x53, 0x74, 0x75, 0x66, 0x66, 0x53, 0x69, 0x67, 0x6e, 0x65,
+	0x72, 0x12, 0x3a, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a,
+	0x04, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x69,
+	0x67, 0x6e, 0x2e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3a, 0x0a, 0x07, 0x42, 0x4c, 0x53, 0x53, 0x69, 0x67, 0x6e, 0x12, 0x16, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x4c, 0x53, 0x53, 0x69, 0x67, 0x6e, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x4c, 0x53, 0x53,
+	0x69, 0x67, 0x6e, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x37, 0x5a, 0x35,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x74, 0x68, 0x65, 0x72,
+	0x65, 0x75, 0x6d, 0x2f, 0x67, 0x6f, 0x2d, 0x65, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x2f,
+	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2f, 0x68, 0x6f, 0x74, 0x73, 0x74, 0x75, 0x66, 0x66, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_hotstuff_proto_rawDescOnce sync.Once
+	file_hotstuff_proto_rawDescData = file_hotstuff_proto_rawDesc
+)
+
+func file_hotstuff_proto_rawDescGZIP() []byte {
+	file_hotstuff_proto_rawDescOnce.Do(func() {
+		file_hotstuff_proto_rawDescData = protoimpl.X.CompressGZIP(file_hotstuff_proto_rawDescData)
+	})
+	return file_hotstuff_proto_rawDescData
+}
+
+var file_hotstuff_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_hotstuff_proto_goTypes = []interface{}{
+	(*Address)(nil),          // 0: proto.Address
+	(*Sign)(nil),             // 1: proto.Sign
+	(*BLSSign)(nil),          // 2: proto.BLSSign
+	(*Address_Request)(nil),  // 3: proto.Address.Request
+	(*Address_Response)(nil), // 4: proto.Address.Response
+	(*Sign_Request)(nil),     // 5: proto.Sign.Request
+	(*Sign_Response)(nil),    // 6: proto.Sign.Response
+	(*BLSSign_Request)(nil),  // 7: proto.BLSSign.Request
+	(*BLSSign_Response)(nil), // 8: proto.BLSSign.Response
+}
+var file_hotstuff_proto_depIdxs = []int32{
+	3, // 0: proto.PluginHotStuffSigner.Address:input_type -> proto.Address.Request
+	5, // 1: proto.PluginHotStuffSigner.Sign:input_type -> proto.Sign.Request
+	7, // 2: proto.PluginHotStuffSigner.BLSSign:input_type -> proto.BLSSign.Request
+	4, // 3: proto.PluginHotStuffSigner.Address:output_type -> proto.Address.Response
+	6, // 4: proto.PluginHotStuffSigner.Sign:output_type -> proto.Sign.Response
+	8, // 5: proto.PluginHotStuffSigner.BLSSign:output_type -> proto.BLSSign.Response
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_hotstuff_proto_init() }
+func file_hotstuff_proto_init() {
+	if File_hotstuff_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_hotstuff_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sign); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BLSSign); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sign_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Sign_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BLSSign_Request); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_hotstuff_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BLSSign_Response); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_hotstuff_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_hotstuff_proto_goTypes,
+		DependencyIndexes: file_hotstuff_proto_depIdxs,
+		MessageInfos:      file_hotstuff_proto_msgTypes,
+	}.Build()
+	File_hotstuff_proto = out.File
+	file_hotstuff_proto_rawDesc = nil
+	file_hotstuff_proto_goTypes = nil
+	file_hotstuff_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// PluginHotStuffSignerClient is the client API for PluginHotStuffSigner service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PluginHotStuffSignerClient interface {
+	// Returns the validator address, derived from the ECDSA public key
+	Address(ctx context.Context, in *Address_Request, opts ...grpc.CallOption) (*Address_Response, error)
+	// Signs a hash with the ECDSA key of the validator
+	Sign(ctx context.Context, in *Sign_Request, opts ...grpc.CallOption) (*Sign_Response, error)
+	// Signs data with the BLS key of the validator
+	BLSSign(ctx context.Context, in *BLSSign_Request, opts ...grpc.CallOption) (*BLSSign_Response, error)
+}
+
+type pluginHotStuffSignerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPluginHotStuffSignerClient(cc grpc.ClientConnInterface) PluginHotStuffSignerClient {
+	return &pluginHotStuffSignerClient{cc}
+}
+
+func (c *pluginHotStuffSignerClient) Address(ctx context.Context, in *Address_Request, opts ...grpc.CallOption) (*Address_Response, error) {
+	out := new(Address_Response)
+	err := c.cc.Invoke(ctx, "/proto.PluginHotStuffSigner/Address", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginHotStuffSignerClient) Sign(ctx context.Context, in *Sign_Request, opts ...grpc.CallOption) (*Sign_Response, error) {
+	out := new(Sign_Response)
+	err := c.cc.Invoke(ctx, "/proto.PluginHotStuffSigner/Sign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pluginHotStuffSignerClient) BLSSign(ctx context.Context, in *BLSSign_Request, opts ...grpc.CallOption) (*BLSSign_Response, error) {
+	out := new(BLSSign_Response)
+	err := c.cc.Invoke(ctx, "/proto.PluginHotStuffSigner/BLSSign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PluginHotStuffSignerServer is the server API for PluginHotStuffSigner service.
+type PluginHotStuffSignerServer interface {
+	// Returns the validator address, derived from the ECDSA public key
+	Address(context.Context, *Address_Request) (*Address_Response, error)
+	// Signs a hash with the ECDSA key of the validator
+	Sign(context.Context, *Sign_Request) (*Sign_Response, error)
+	// Signs data with the BLS key of the validator
+	BLSSign(context.Context, *BLSSign_Request) (*BLSSign_Response, error)
+}
+
+// UnimplementedPluginHotStuffSignerServer can be embedded to have forward compatible implementations.
+type UnimplementedPluginHotStuffSignerServer struct {
+}
+
+func (*UnimplementedPluginHotStuffSignerServer) Address(context.Context, *Address_Request) (*Address_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Address not implemented")
+}
+func (*UnimplementedPluginHotStuffSignerServer) Sign(context.Context, *Sign_Request) (*Sign_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Sign not implemented")
+}
+func (*UnimplementedPluginHotStuffSignerServer) BLSSign(context.Context, *BLSSign_Request) (*BLSSign_Response, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BLSSign not implemented")
+}
+
+func RegisterPluginHotStuffSignerServer(s *grpc.Server, srv PluginHotStuffSignerServer) {
+	s.RegisterService(&_PluginHotStuffSigner_serviceDesc, srv)
+}
+
+func _PluginHotStuffSigner_Address_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginHotStuffSignerServer).Address(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PluginHotStuffSigner/Address",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginHotStuffSignerServer).Address(ctx, req.(*Address_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginHotStuffSigner_Sign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Sign_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginHotStuffSignerServer).Sign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PluginHotStuffSigner/Sign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginHotStuffSignerServer).Sign(ctx, req.(*Sign_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PluginHotStuffSigner_BLSSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BLSSign_Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PluginHotStuffSignerServer).BLSSign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.PluginHotStuffSigner/BLSSign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PluginHotStuffSignerServer).BLSSign(ctx, req.(*BLSSign_Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _PluginHotStuffSigner_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.PluginHotStuffSigner",
+	HandlerType: (*PluginHotStuffSignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Address",
+			Handler:    _PluginHotStuffSigner_Address_Handler,
+		},
+		{
+			MethodName: "Sign",
+			Handler:    _PluginHotStuffSigner_Sign_Handler,
+		},
+		{
+			MethodName: "BLSSign",
+			Handler:    _PluginHotStuffSigner_BLSSign_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "hotstuff.proto",
+}
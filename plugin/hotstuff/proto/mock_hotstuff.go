@@ -0,0 +1,164 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../hotstuff/proto/hotstuff.pb.go
+
+// Package proto is a generated GoMock package.
+package proto
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	grpc "google.golang.org/grpc"
+)
+
+// MockPluginHotStuffSignerClient is a mock of PluginHotStuffSignerClient interface.
+type MockPluginHotStuffSignerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPluginHotStuffSignerClientMockRecorder
+}
+
+// MockPluginHotStuffSignerClientMockRecorder is the mock recorder for MockPluginHotStuffSignerClient.
+type MockPluginHotStuffSignerClientMockRecorder struct {
+	mock *MockPluginHotStuffSignerClient
+}
+
+// NewMockPluginHotStuffSignerClient creates a new mock instance.
+func NewMockPluginHotStuffSignerClient(ctrl *gomock.Controller) *MockPluginHotStuffSignerClient {
+	mock := &MockPluginHotStuffSignerClient{ctrl: ctrl}
+	mock.recorder = &MockPluginHotStuffSignerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPluginHotStuffSignerClient) EXPECT() *MockPluginHotStuffSignerClientMockRecorder {
+	return m.recorder
+}
+
+// Address mocks base method.
+func (m *MockPluginHotStuffSignerClient) Address(ctx context.Context, in *Address_Request, opts ...grpc.CallOption) (*Address_Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Address", varargs...)
+	ret0, _ := ret[0].(*Address_Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Address indicates an expected call of Address.
+func (mr *MockPluginHotStuffSignerClientMockRecorder) Address(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockPluginHotStuffSignerClient)(nil).Address), varargs...)
+}
+
+// BLSSign mocks base method.
+func (m *MockPluginHotStuffSignerClient) BLSSign(ctx context.Context, in *BLSSign_Request, opts ...grpc.CallOption) (*BLSSign_Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BLSSign", varargs...)
+	ret0, _ := ret[0].(*BLSSign_Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BLSSign indicates an expected call of BLSSign.
+func (mr *MockPluginHotStuffSignerClientMockRecorder) BLSSign(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BLSSign", reflect.TypeOf((*MockPluginHotStuffSignerClient)(nil).BLSSign), varargs...)
+}
+
+// Sign mocks base method.
+func (m *MockPluginHotStuffSignerClient) Sign(ctx context.Context, in *Sign_Request, opts ...grpc.CallOption) (*Sign_Response, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Sign", varargs...)
+	ret0, _ := ret[0].(*Sign_Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockPluginHotStuffSignerClientMockRecorder) Sign(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockPluginHotStuffSignerClient)(nil).Sign), varargs...)
+}
+
+// MockPluginHotStuffSignerServer is a mock of PluginHotStuffSignerServer interface.
+type MockPluginHotStuffSignerServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockPluginHotStuffSignerServerMockRecorder
+}
+
+// MockPluginHotStuffSignerServerMockRecorder is the mock recorder for MockPluginHotStuffSignerServer.
+type MockPluginHotStuffSignerServerMockRecorder struct {
+	mock *MockPluginHotStuffSignerServer
+}
+
+// NewMockPluginHotStuffSignerServer creates a new mock instance.
+func NewMockPluginHotStuffSignerServer(ctrl *gomock.Controller) *MockPluginHotStuffSignerServer {
+	mock := &MockPluginHotStuffSignerServer{ctrl: ctrl}
+	mock.recorder = &MockPluginHotStuffSignerServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPluginHotStuffSignerServer) EXPECT() *MockPluginHotStuffSignerServerMockRecorder {
+	return m.recorder
+}
+
+// Address mocks base method.
+func (m *MockPluginHotStuffSignerServer) Address(arg0 context.Context, arg1 *Address_Request) (*Address_Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Address", arg0, arg1)
+	ret0, _ := ret[0].(*Address_Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Address indicates an expected call of Address.
+func (mr *MockPluginHotStuffSignerServerMockRecorder) Address(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Address", reflect.TypeOf((*MockPluginHotStuffSignerServer)(nil).Address), arg0, arg1)
+}
+
+// BLSSign mocks base method.
+func (m *MockPluginHotStuffSignerServer) BLSSign(arg0 context.Context, arg1 *BLSSign_Request) (*BLSSign_Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BLSSign", arg0, arg1)
+	ret0, _ := ret[0].(*BLSSign_Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BLSSign indicates an expected call of BLSSign.
+func (mr *MockPluginHotStuffSignerServerMockRecorder) BLSSign(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BLSSign", reflect.TypeOf((*MockPluginHotStuffSignerServer)(nil).BLSSign), arg0, arg1)
+}
+
+// Sign mocks base method.
+func (m *MockPluginHotStuffSignerServer) Sign(arg0 context.Context, arg1 *Sign_Request) (*Sign_Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", arg0, arg1)
+	ret0, _ := ret[0].(*Sign_Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockPluginHotStuffSignerServerMockRecorder) Sign(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockPluginHotStuffSignerServer)(nil).Sign), arg0, arg1)
+}
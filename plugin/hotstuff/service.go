@@ -0,0 +1,48 @@
+package hotstuff
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// PluginSigner signs HotStuff proposals, messages and votes with validator keys
+// held by the plugin
+type PluginSigner interface {
+	// Address returns the validator address of the ECDSA key
+	Address(ctx context.Context) (common.Address, error)
+	// Sign signs a hash with the ECDSA key
+	Sign(ctx context.Context, hash common.Hash) ([]byte, error)
+	// BLSSign signs data with the BLS key or key shares
+	BLSSign(ctx context.Context, data []byte) ([]byte, error)
+}
+
+type PluginSignerDeferFunc func() (PluginSigner, error)
+
+type ReloadablePluginSigner struct {
+	DeferFunc PluginSignerDeferFunc
+}
+
+func (d *ReloadablePluginSigner) Address(ctx context.Context) (common.Address, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return p.Address(ctx)
+}
+
+func (d *ReloadablePluginSigner) Sign(ctx context.Context, hash common.Hash) ([]byte, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return nil, err
+	}
+	return p.Sign(ctx, hash)
+}
+
+func (d *ReloadablePluginSigner) BLSSign(ctx context.Context, data []byte) ([]byte, error) {
+	p, err := d.DeferFunc()
+	if err != nil {
+		return nil, err
+	}
+	return p.BLSSign(ctx, data)
+}
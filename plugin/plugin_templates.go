@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/plugin/account"
 	"github.com/ethereum/go-ethereum/plugin/helloworld"
+	"github.com/ethereum/go-ethereum/plugin/hotstuff"
 	"github.com/ethereum/go-ethereum/plugin/qlight"
 	"github.com/ethereum/go-ethereum/plugin/security"
 	"google.golang.org/grpc/codes"
@@ -98,6 +99,23 @@ func (f *ReloadableAccountServiceFactory) Create() (account.Service, error) {
 	return am, nil
 }
 
+// a template that returns the HotStuff signer plugin instance
+type HotStuffSignerPluginTemplate struct {
+	*basePlugin
+}
+
+func (p *HotStuffSignerPluginTemplate) Get() (hotstuff.PluginSigner, error) {
+	return &hotstuff.ReloadablePluginSigner{
+		DeferFunc: func() (hotstuff.PluginSigner, error) {
+			raw, err := p.dispense(hotstuff.ConnectorName)
+			if err != nil {
+				return nil, err
+			}
+			return raw.(hotstuff.PluginSigner), nil
+		},
+	}, nil
+}
+
 type QLightTokenManagerPluginTemplate struct {
 	*basePlugin
 }
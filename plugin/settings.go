@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/plugin/account"
 	"github.com/ethereum/go-ethereum/plugin/helloworld"
+	"github.com/ethereum/go-ethereum/plugin/hotstuff"
 	"github.com/ethereum/go-ethereum/plugin/qlight"
 	"github.com/ethereum/go-ethereum/plugin/security"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -25,6 +26,7 @@ const (
 	SecurityPluginInterfaceName           = PluginInterfaceName("security")
 	AccountPluginInterfaceName            = PluginInterfaceName("account")
 	QLightTokenManagerPluginInterfaceName = PluginInterfaceName("qlighttokenmanager")
+	HotStuffSignerPluginInterfaceName     = PluginInterfaceName("hotstuffsigner")
 )
 
 var (
@@ -83,6 +85,11 @@ var (
 				qlight.ConnectorName: &qlight.PluginConnector{},
 			},
 		},
+		HotStuffSignerPluginInterfaceName: {
+			pluginSet: plugin.PluginSet{
+				hotstuff.ConnectorName: &hotstuff.PluginConnector{},
+			},
+		},
 	}
 
 	// this is the place holder for future solution of the plugin central